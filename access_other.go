@@ -0,0 +1,19 @@
+//go:build !unix
+
+package flags
+
+import "os"
+
+// checkWritable relies on the permission bits, without access(2).
+func checkWritable(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.Mode().Perm()&0o200 == 0 {
+		return os.ErrPermission
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build unix
+
+package flags
+
+import "syscall"
+
+// accessWrite is the W_OK mode of access(2).
+const accessWrite = 0x2
+
+func checkWritable(path string) error {
+	return syscall.Access(path, accessWrite)
+}
@@ -2,17 +2,33 @@ package flags
 
 import (
 	"flag"
+	"log/slog"
+	"net/netip"
+	"net/url"
+	"regexp"
 	"time"
 )
 
 type Builder struct {
-	prefix       string
-	docPrefix    string
-	name         string
-	shorthand    string
-	label        string
-	env          string
-	envSeparator string
+	prefix         string
+	docPrefix      string
+	name           string
+	shorthand      string
+	label          string
+	env            string
+	envSeparator   string
+	kvSeparator    string
+	typeName       string
+	redact         func(string) string
+	defaultErr     error
+	aliases        []string
+	deprecatedEnvs []string
+	duplicates     DuplicatePolicy
+	merge          MergePolicy
+	hidden         bool
+	sensitive      bool
+	quoted         bool
+	indexedEnv     bool
 }
 
 func New(name, label string) Builder {
@@ -20,6 +36,19 @@ func New(name, label string) Builder {
 		name:         firstUpperCase(name),
 		label:        label,
 		envSeparator: ",",
+		kvSeparator:  "=",
+	}
+}
+
+func newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator string) Builder {
+	return Builder{
+		prefix:       prefix,
+		docPrefix:    docPrefix,
+		name:         name,
+		shorthand:    shorthand,
+		label:        label,
+		env:          env,
+		envSeparator: envSeparator,
 	}
 }
 
@@ -53,82 +82,473 @@ func (b Builder) EnvSeparator(envSeparator string) Builder {
 	return b
 }
 
+// KeyValueSeparator defines the separator between key and value of map flags, `=` by default.
+func (b Builder) KeyValueSeparator(kvSeparator string) Builder {
+	b.kvSeparator = kvSeparator
+
+	return b
+}
+
+// DuplicateKeys defines how a key given twice to a map flag is handled, the last value is kept by default.
+func (b Builder) DuplicateKeys(duplicates DuplicatePolicy) Builder {
+	b.duplicates = duplicates
+
+	return b
+}
+
+// Quoted enables double quotes and backslash escaping when splitting values of slice and map flags, e.g. `"a,b",c\,d` gives `a,b` and `c,d`.
+// Arguments of slice flags are split the same way as environment variable.
+func (b Builder) Quoted() Builder {
+	b.quoted = true
+
+	return b
+}
+
+// IndexedEnv reads slice flags from indexed environment variables `${ENV_0}`, `${ENV_1}`... as an alternative to the separated `${ENV}`.
+func (b Builder) IndexedEnv() Builder {
+	b.indexedEnv = true
+
+	return b
+}
+
+// Merge defines how values of a slice flag from default, environment variable and arguments are combined, replaced by default.
+func (b Builder) Merge(merge MergePolicy) Builder {
+	b.merge = merge
+
+	return b
+}
+
+// typed sets the type name displayed in Usage for values not defined by the flag package.
+func (b Builder) typed(typeName string) Builder {
+	b.typeName = typeName
+
+	return b
+}
+
+// Aliases declares deprecated names of the flag, still accepted as argument but with a warning.
+func (b Builder) Aliases(aliases ...string) Builder {
+	b.aliases = aliases
+
+	return b
+}
+
+// DeprecatedEnv declares deprecated environment variables, read when the main one is not set but with a warning.
+func (b Builder) DeprecatedEnv(envs ...string) Builder {
+	b.deprecatedEnvs = envs
+
+	return b
+}
+
+// Hidden omits the flag from Usage, unless `flags.WithHidden()` option is given. It's still parsed from argument and environment variable.
+func (b Builder) Hidden() Builder {
+	b.hidden = true
+
+	return b
+}
+
+// Sensitive redacts the flag's value from Usage and configuration outputs.
+func (b Builder) Sensitive() Builder {
+	b.sensitive = true
+
+	return b
+}
+
 func (b Builder) String(fs *flag.FlagSet, value string, overrides []Override) *string {
-	return String(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(string)
+
+	b.StringVar(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Int(fs *flag.FlagSet, value int, overrides []Override) *int {
-	return Int(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(int)
+
+	b.IntVar(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Int64(fs *flag.FlagSet, value int64, overrides []Override) *int64 {
-	return Int64(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(int64)
+
+	b.Int64Var(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Uint(fs *flag.FlagSet, value uint, overrides []Override) *uint {
-	return Uint(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(uint)
+
+	b.UintVar(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Uint64(fs *flag.FlagSet, value uint64, overrides []Override) *uint64 {
-	return Uint64(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(uint64)
+
+	b.Uint64Var(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Float64(fs *flag.FlagSet, value float64, overrides []Override) *float64 {
-	return Float64(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(float64)
+
+	b.Float64Var(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Float64Slice(fs *flag.FlagSet, value []float64, overrides []Override) *[]float64 {
-	return Float64Slice(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, b.envSeparator, value, overrides)
+	output := new([]float64)
+
+	b.Float64SliceVar(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Bool(fs *flag.FlagSet, value bool, overrides []Override) *bool {
-	return Bool(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(bool)
+
+	b.BoolVar(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) Duration(fs *flag.FlagSet, value time.Duration, overrides []Override) *time.Duration {
-	return Duration(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+	output := new(time.Duration)
+
+	b.DurationVar(fs, output, value, overrides)
+
+	return output
 }
 
 func (b Builder) StringSlice(fs *flag.FlagSet, value []string, overrides []Override) *[]string {
-	return StringSlice(fs, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, b.envSeparator, value, overrides)
+	output := new([]string)
+
+	b.StringSliceVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) StringVar(fs *flag.FlagSet, output *string, value string, overrides []Override) {
-	StringVar(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) IntSlice(fs *flag.FlagSet, value []int, overrides []Override) *[]int {
+	output := new([]int)
+
+	b.IntSliceVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) IntVar(fs *flag.FlagSet, output *int, value int, overrides []Override) {
-	IntVar(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) Int64Slice(fs *flag.FlagSet, value []int64, overrides []Override) *[]int64 {
+	output := new([]int64)
+
+	b.Int64SliceVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) Int64Var(fs *flag.FlagSet, output *int64, value int64, overrides []Override) {
-	Int64Var(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) UintSlice(fs *flag.FlagSet, value []uint, overrides []Override) *[]uint {
+	output := new([]uint)
+
+	b.UintSliceVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) UintVar(fs *flag.FlagSet, output *uint, value uint, overrides []Override) {
-	UintVar(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) DurationSlice(fs *flag.FlagSet, value []time.Duration, overrides []Override) *[]time.Duration {
+	output := new([]time.Duration)
+
+	b.DurationSliceVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) Uint64Var(fs *flag.FlagSet, output *uint64, value uint64, overrides []Override) {
-	Uint64Var(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) BoolSlice(fs *flag.FlagSet, value []bool, overrides []Override) *[]bool {
+	output := new([]bool)
+
+	b.BoolSliceVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) Float64Var(fs *flag.FlagSet, output *float64, value float64, overrides []Override) {
-	Float64Var(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) StringMap(fs *flag.FlagSet, value map[string]string, overrides []Override) *map[string]string {
+	output := new(map[string]string)
+
+	b.StringMapVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) BoolVar(fs *flag.FlagSet, output *bool, value bool, overrides []Override) {
-	BoolVar(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) Counter(fs *flag.FlagSet, value int, overrides []Override) *int {
+	output := new(int)
+
+	b.CounterVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) DurationVar(fs *flag.FlagSet, output *time.Duration, value time.Duration, overrides []Override) {
-	DurationVar(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, value, overrides)
+func (b Builder) OptionalString(fs *flag.FlagSet, overrides []Override) *Optional[string] {
+	output := new(Optional[string])
+
+	b.OptionalStringVar(fs, output, overrides)
+
+	return output
+}
+
+func (b Builder) OptionalInt(fs *flag.FlagSet, overrides []Override) *Optional[int] {
+	output := new(Optional[int])
+
+	b.OptionalIntVar(fs, output, overrides)
+
+	return output
 }
 
-func (b Builder) StringSliceVar(fs *flag.FlagSet, output *[]string, value []string, overrides []Override) {
-	StringSliceVar(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, b.envSeparator, value, overrides)
+func (b Builder) OptionalBool(fs *flag.FlagSet, overrides []Override) *Optional[bool] {
+	output := new(Optional[bool])
+
+	b.OptionalBoolVar(fs, output, overrides)
+
+	return output
+}
+
+func (b Builder) OptionalDuration(fs *flag.FlagSet, overrides []Override) *Optional[time.Duration] {
+	output := new(Optional[time.Duration])
+
+	b.OptionalDurationVar(fs, output, overrides)
+
+	return output
+}
+
+func (b Builder) IP(fs *flag.FlagSet, value netip.Addr, overrides []Override) *netip.Addr {
+	output := new(netip.Addr)
+
+	b.IPVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) IPPrefix(fs *flag.FlagSet, value netip.Prefix, overrides []Override) *netip.Prefix {
+	output := new(netip.Prefix)
+
+	b.IPPrefixVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) AddrPort(fs *flag.FlagSet, value netip.AddrPort, overrides []Override) *netip.AddrPort {
+	output := new(netip.AddrPort)
+
+	b.AddrPortVar(fs, output, value, overrides)
+
+	return output
 }
 
-func (b Builder) Float64SliceVar(fs *flag.FlagSet, output *[]float64, value []float64, overrides []Override) {
-	Float64SliceVar(fs, output, b.prefix, b.docPrefix, b.name, b.shorthand, b.label, b.env, b.envSeparator, value, overrides)
+func (b Builder) HostPort(fs *flag.FlagSet, value string, overrides []Override) *string {
+	output := new(string)
+
+	b.HostPortVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) IPSlice(fs *flag.FlagSet, value []netip.Addr, overrides []Override) *[]netip.Addr {
+	output := new([]netip.Addr)
+
+	b.IPSliceVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) IPPrefixSlice(fs *flag.FlagSet, value []netip.Prefix, overrides []Override) *[]netip.Prefix {
+	output := new([]netip.Prefix)
+
+	b.IPPrefixSliceVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) AddrPortSlice(fs *flag.FlagSet, value []netip.AddrPort, overrides []Override) *[]netip.AddrPort {
+	output := new([]netip.AddrPort)
+
+	b.AddrPortSliceVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) HostPortSlice(fs *flag.FlagSet, value []string, overrides []Override) *[]string {
+	output := new([]string)
+
+	b.HostPortSliceVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) URL(fs *flag.FlagSet, value string, overrides []Override, constraints ...URLConstraint) *url.URL {
+	output := new(url.URL)
+
+	b.URLVar(fs, output, value, overrides, constraints...)
+
+	return output
+}
+
+func (b Builder) ByteSize(fs *flag.FlagSet, value ByteSize, overrides []Override) *ByteSize {
+	output := new(ByteSize)
+
+	b.ByteSizeVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Time(fs *flag.FlagSet, value time.Time, overrides []Override, layouts ...string) *time.Time {
+	output := new(time.Time)
+
+	b.TimeVar(fs, output, value, overrides, layouts...)
+
+	return output
+}
+
+func (b Builder) Date(fs *flag.FlagSet, value time.Time, overrides []Override) *time.Time {
+	output := new(time.Time)
+
+	b.DateVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Location(fs *flag.FlagSet, value *time.Location, overrides []Override) **time.Location {
+	output := new(*time.Location)
+
+	b.LocationVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Regexp(fs *flag.FlagSet, value *regexp.Regexp, overrides []Override) **regexp.Regexp {
+	output := new(*regexp.Regexp)
+
+	b.RegexpVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) RegexpSlice(fs *flag.FlagSet, values []*regexp.Regexp, overrides []Override) *[]*regexp.Regexp {
+	output := new([]*regexp.Regexp)
+
+	b.RegexpSliceVar(fs, output, values, overrides)
+
+	return output
+}
+
+func (b Builder) File(fs *flag.FlagSet, value string, overrides []Override, constraints ...PathConstraint) *string {
+	output := new(string)
+
+	b.FileVar(fs, output, value, overrides, constraints...)
+
+	return output
+}
+
+func (b Builder) Dir(fs *flag.FlagSet, value string, overrides []Override, constraints ...PathConstraint) *string {
+	output := new(string)
+
+	b.DirVar(fs, output, value, overrides, constraints...)
+
+	return output
+}
+
+func (b Builder) FileContent(fs *flag.FlagSet, value string, overrides []Override, constraints ...PathConstraint) *[]byte {
+	output := new([]byte)
+
+	b.FileContentVar(fs, output, value, overrides, constraints...)
+
+	return output
+}
+
+func (b Builder) HexBytes(fs *flag.FlagSet, value []byte, overrides []Override, constraints ...BytesConstraint) *[]byte {
+	output := new([]byte)
+
+	b.HexBytesVar(fs, output, value, overrides, constraints...)
+
+	return output
+}
+
+func (b Builder) Base64Bytes(fs *flag.FlagSet, value []byte, overrides []Override, constraints ...BytesConstraint) *[]byte {
+	output := new([]byte)
+
+	b.Base64BytesVar(fs, output, value, overrides, constraints...)
+
+	return output
+}
+
+func (b Builder) SlogLevel(fs *flag.FlagSet, value slog.Level, overrides []Override) *slog.LevelVar {
+	output := new(slog.LevelVar)
+
+	b.SlogLevelVar(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Int8(fs *flag.FlagSet, value int8, overrides []Override) *int8 {
+	output := new(int8)
+
+	b.Int8Var(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Int16(fs *flag.FlagSet, value int16, overrides []Override) *int16 {
+	output := new(int16)
+
+	b.Int16Var(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Int32(fs *flag.FlagSet, value int32, overrides []Override) *int32 {
+	output := new(int32)
+
+	b.Int32Var(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Uint8(fs *flag.FlagSet, value uint8, overrides []Override) *uint8 {
+	output := new(uint8)
+
+	b.Uint8Var(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Uint16(fs *flag.FlagSet, value uint16, overrides []Override) *uint16 {
+	output := new(uint16)
+
+	b.Uint16Var(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Uint32(fs *flag.FlagSet, value uint32, overrides []Override) *uint32 {
+	output := new(uint32)
+
+	b.Uint32Var(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Float32(fs *flag.FlagSet, value float32, overrides []Override) *float32 {
+	output := new(float32)
+
+	b.Float32Var(fs, output, value, overrides)
+
+	return output
+}
+
+func (b Builder) Ratio(fs *flag.FlagSet, value float64, overrides []Override) *float64 {
+	output := new(float64)
+
+	b.RatioVar(fs, output, value, overrides)
+
+	return output
 }
@@ -0,0 +1,100 @@
+package flags
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// BytesConstraint validates decoded bytes.
+type BytesConstraint func([]byte) error
+
+// Length rejects bytes that are not exactly length long, e.g. Length(32) for an AES-256 key.
+func Length(length int) BytesConstraint {
+	return func(value []byte) error {
+		if len(value) != length {
+			return fmt.Errorf("decoded length is %d bytes, want %d", len(value), length)
+		}
+
+		return nil
+	}
+}
+
+func parseBytes(decode func(string) ([]byte, error), constraints []BytesConstraint) func(string) ([]byte, error) {
+	return func(input string) ([]byte, error) {
+		if len(input) == 0 {
+			return nil, nil
+		}
+
+		output, err := decode(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, constraint := range constraints {
+			if err := constraint(output); err != nil {
+				return nil, err
+			}
+		}
+
+		return output, nil
+	}
+}
+
+// decodeHex decodes hexadecimal input with errors giving only positions, since the input is a secret.
+func decodeHex(input string) ([]byte, error) {
+	output, err := hex.DecodeString(input)
+	if err == nil {
+		return output, nil
+	}
+
+	if offset := strings.IndexFunc(input, func(r rune) bool {
+		return !strings.ContainsRune("0123456789abcdefABCDEF", r)
+	}); offset != -1 {
+		return nil, fmt.Errorf("invalid hex character at offset %d", offset)
+	}
+
+	if errors.Is(err, hex.ErrLength) {
+		return nil, errors.New("odd length hex string")
+	}
+
+	return nil, errors.New("invalid hex string")
+}
+
+// decodeBase64 decodes base64 input with errors giving only positions, since the input is a secret.
+func decodeBase64(input string) ([]byte, error) {
+	output, err := base64.StdEncoding.DecodeString(input)
+	if err == nil {
+		return output, nil
+	}
+
+	for _, encoding := range []*base64.Encoding{base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if output, otherErr := encoding.DecodeString(input); otherErr == nil {
+			return output, nil
+		}
+	}
+
+	var corruptErr base64.CorruptInputError
+	if errors.As(err, &corruptErr) {
+		return nil, fmt.Errorf("invalid base64 character at offset %d", int64(corruptErr))
+	}
+
+	return nil, errors.New("invalid base64 string")
+}
+
+func encodeBase64(value []byte) string {
+	return base64.StdEncoding.EncodeToString(value)
+}
+
+// HexBytesVar binds a flag decoded from hexadecimal. It is sensitive by default, and its errors don't contain the value.
+func (b Builder) HexBytesVar(fs *flag.FlagSet, output *[]byte, value []byte, overrides []Override, constraints ...BytesConstraint) {
+	valueVar(b.Sensitive().typed("hex"), fs, output, value, overrides, parseBytes(decodeHex, constraints), hex.EncodeToString)
+}
+
+// Base64BytesVar binds a flag decoded from base64, standard or URL alphabet, padded or not. It is sensitive by default, and its errors don't contain the value.
+func (b Builder) Base64BytesVar(fs *flag.FlagSet, output *[]byte, value []byte, overrides []Override, constraints ...BytesConstraint) {
+	valueVar(b.Sensitive().typed("base64"), fs, output, value, overrides, parseBytes(decodeBase64, constraints), encodeBase64)
+}
@@ -0,0 +1,128 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHexBytes(t *testing.T) {
+	cases := map[string]struct {
+		preTest  func(*testing.T)
+		args     []string
+		want     []byte
+		wantErr  string
+		wantEnv  string
+		wantDump string
+	}{
+		"default": {
+			nil,
+			nil,
+			[]byte{0xde, 0xad},
+			"",
+			"",
+			"*****",
+		},
+		"argument": {
+			nil,
+			[]string{"--key", "CAFE"},
+			[]byte{0xca, 0xfe},
+			"",
+			"",
+			"*****",
+		},
+		"invalid argument": {
+			nil,
+			[]string{"--key", "cafeteria"},
+			[]byte{0xde, 0xad},
+			"invalid value \"cafeteria\" for flag -key: invalid hex character at offset 4",
+			"",
+			"*****",
+		},
+		"invalid length": {
+			nil,
+			[]string{"--key", "cafe00"},
+			[]byte{0xde, 0xad},
+			"invalid value \"cafe00\" for flag -key: decoded length is 3 bytes, want 2",
+			"",
+			"*****",
+		},
+		"invalid env": {
+			func(t *testing.T) {
+				t.Setenv("SECRET_KEY", "zz")
+			},
+			nil,
+			[]byte{0xde, 0xad},
+			"",
+			"env `SECRET_KEY` of flag `key`: invalid hex character at offset 0",
+			"*****",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			if testCase.preTest != nil {
+				testCase.preTest(t)
+			}
+
+			fs := flag.NewFlagSet("Secret", flag.ContinueOnError)
+			fs.SetOutput(&strings.Builder{})
+
+			got := flags.New("key", "Signing key").HexBytes(fs, []byte{0xde, 0xad}, nil, flags.Length(2))
+
+			if testCase.wantEnv != "" {
+				assert.EqualError(t, flags.Validate(fs), testCase.wantEnv)
+			} else {
+				assert.NoError(t, flags.Validate(fs))
+			}
+
+			err := fs.Parse(testCase.args)
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantDump, flags.Dump(fs)[0].Value)
+		})
+	}
+}
+
+func TestBase64Bytes(t *testing.T) {
+	t.Setenv("HMAC_SECRET", "c2VjcmV0")
+
+	fs := flag.NewFlagSet("Hmac", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	secret := flags.New("secret", "HMAC secret").Base64Bytes(fs, nil, nil)
+	salt := flags.New("salt", "Salt").Base64Bytes(fs, []byte("salt"), nil)
+	fs.Usage()
+
+	assert.Equal(t, []byte("secret"), *secret)
+	assert.Equal(t, "Usage of Hmac:\n  --salt    base64  Salt ${HMAC_SALT} (default *****)\n  --secret  base64  HMAC secret ${HMAC_SECRET} (default *****)\n", writer.String())
+
+	assert.NoError(t, fs.Parse([]string{"--salt", "c2FsdC1fPw"}))
+	assert.Equal(t, []byte("salt-_?"), *salt)
+}
+
+func TestBytesEnvError(t *testing.T) {
+	t.Setenv("LEAK_KEY", "abc")
+	t.Setenv("LEAK_SECRET", "c2Vj!mV0")
+
+	fs := flag.NewFlagSet("Leak", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	flags.New("key", "Key").HexBytes(fs, nil, nil)
+	flags.New("secret", "Secret").Base64Bytes(fs, nil, nil)
+
+	err := flags.Validate(fs)
+	assert.EqualError(t, err, "env `LEAK_KEY` of flag `key`: odd length hex string\nenv `LEAK_SECRET` of flag `secret`: invalid base64 character at offset 4")
+	assert.NotContains(t, err.Error(), "c2Vj")
+}
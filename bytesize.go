@@ -0,0 +1,141 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ByteSize is a number of bytes, parsed from and rendered with SI (kB, MB, ...) or IEC (KiB, MiB, ...) units.
+type ByteSize uint64
+
+const (
+	Byte ByteSize = 1
+
+	Kilobyte ByteSize = 1000 * Byte
+	Megabyte ByteSize = 1000 * Kilobyte
+	Gigabyte ByteSize = 1000 * Megabyte
+	Terabyte ByteSize = 1000 * Gigabyte
+	Petabyte ByteSize = 1000 * Terabyte
+	Exabyte  ByteSize = 1000 * Petabyte
+
+	Kibibyte ByteSize = 1024 * Byte
+	Mebibyte ByteSize = 1024 * Kibibyte
+	Gibibyte ByteSize = 1024 * Mebibyte
+	Tebibyte ByteSize = 1024 * Gibibyte
+	Pebibyte ByteSize = 1024 * Tebibyte
+	Exbibyte ByteSize = 1024 * Pebibyte
+)
+
+type byteUnit struct {
+	name string
+	size ByteSize
+}
+
+// byteUnits are ordered from the largest to the smallest, for rendering.
+var byteUnits = []byteUnit{
+	{"EiB", Exbibyte},
+	{"EB", Exabyte},
+	{"PiB", Pebibyte},
+	{"PB", Petabyte},
+	{"TiB", Tebibyte},
+	{"TB", Terabyte},
+	{"GiB", Gibibyte},
+	{"GB", Gigabyte},
+	{"MiB", Mebibyte},
+	{"MB", Megabyte},
+	{"KiB", Kibibyte},
+	{"kB", Kilobyte},
+}
+
+// ParseByteSize parses a size such as `512KiB`, `10MB` or `1.5GiB`. A number without unit is a number of bytes, units are case-insensitive.
+func ParseByteSize(input string) (ByteSize, error) {
+	trimmed := strings.TrimSpace(input)
+
+	index := strings.IndexFunc(trimmed, func(r rune) bool {
+		return r != '.' && !unicode.IsDigit(r)
+	})
+	if index == -1 {
+		index = len(trimmed)
+	}
+
+	number, unitName := trimmed[:index], strings.TrimSpace(trimmed[index:])
+	if len(number) == 0 {
+		return 0, fmt.Errorf("invalid size `%s`", input)
+	}
+
+	unit := Byte
+	if len(unitName) > 0 && !strings.EqualFold(unitName, "B") {
+		var found bool
+
+		for _, item := range byteUnits {
+			if strings.EqualFold(unitName, item.name) {
+				unit, found = item.size, true
+				break
+			}
+		}
+
+		if !found {
+			return 0, fmt.Errorf("invalid unit `%s` of size `%s`", unitName, input)
+		}
+	}
+
+	if count, err := strconv.ParseUint(number, 10, 64); err == nil {
+		hi, lo := bits.Mul64(count, uint64(unit))
+		if hi != 0 {
+			return 0, fmt.Errorf("size `%s` overflows", input)
+		}
+
+		return ByteSize(lo), nil
+	}
+
+	count, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size `%s`", input)
+	}
+
+	size := math.Round(count * float64(unit))
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("size `%s` overflows", input)
+	}
+
+	return ByteSize(size), nil
+}
+
+// String renders the size with the unit giving the shortest exact value, with up to two decimals, e.g. `1.5GiB`.
+func (b ByteSize) String() string {
+	for _, unit := range byteUnits {
+		if b < unit.size {
+			continue
+		}
+
+		scale := uint64(1)
+		for decimals := 0; decimals <= 2; decimals++ {
+			hi, lo := bits.Mul64(uint64(b), scale)
+
+			if hi < uint64(unit.size) {
+				quotient, remainder := bits.Div64(hi, lo, uint64(unit.size))
+				if remainder == 0 {
+					if decimals == 0 {
+						return fmt.Sprintf("%d%s", quotient, unit.name)
+					}
+
+					return fmt.Sprintf("%d.%0*d%s", quotient/scale, decimals, quotient%scale, unit.name)
+				}
+			}
+
+			scale *= 10
+		}
+	}
+
+	return fmt.Sprintf("%dB", uint64(b))
+}
+
+// ByteSizeVar binds a size flag, rendered with human units in Usage.
+func (b Builder) ByteSizeVar(fs *flag.FlagSet, output *ByteSize, value ByteSize, overrides []Override) {
+	valueVar(b.typed("size"), fs, output, value, overrides, ParseByteSize, ByteSize.String)
+}
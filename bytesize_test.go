@@ -0,0 +1,144 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]struct {
+		input   string
+		want    flags.ByteSize
+		wantErr string
+	}{
+		"bytes": {
+			"512",
+			512,
+			"",
+		},
+		"bytes unit": {
+			"512B",
+			512,
+			"",
+		},
+		"iec": {
+			"512KiB",
+			512 * flags.Kibibyte,
+			"",
+		},
+		"si": {
+			"10MB",
+			10 * flags.Megabyte,
+			"",
+		},
+		"decimal": {
+			"1.5GiB",
+			3 * flags.Gibibyte / 2,
+			"",
+		},
+		"case insensitive with space": {
+			" 2 kb ",
+			2 * flags.Kilobyte,
+			"",
+		},
+		"unknown unit": {
+			"12parsecs",
+			0,
+			"invalid unit `parsecs` of size `12parsecs`",
+		},
+		"no number": {
+			"MiB",
+			0,
+			"invalid size `MiB`",
+		},
+		"negative": {
+			"-1KiB",
+			0,
+			"invalid size `-1KiB`",
+		},
+		"overflow": {
+			"20EiB",
+			0,
+			"size `20EiB` overflows",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			got, err := flags.ParseByteSize(testCase.input)
+
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestByteSizeString(t *testing.T) {
+	cases := map[string]struct {
+		input flags.ByteSize
+		want  string
+	}{
+		"zero": {
+			0,
+			"0B",
+		},
+		"bytes": {
+			1023,
+			"1023B",
+		},
+		"iec": {
+			512 * flags.Kibibyte,
+			"512KiB",
+		},
+		"si": {
+			10 * flags.Megabyte,
+			"10MB",
+		},
+		"decimal": {
+			3 * flags.Gibibyte / 2,
+			"1.5GiB",
+		},
+		"two decimals": {
+			1250 * flags.Kilobyte,
+			"1.25MB",
+		},
+		"not exact": {
+			flags.Megabyte + 1,
+			"1000001B",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			assert.Equal(t, testCase.want, testCase.input.String())
+		})
+	}
+}
+
+func TestByteSize(t *testing.T) {
+	t.Setenv("CACHE_MEMORY", "1.5GiB")
+
+	fs := flag.NewFlagSet("Cache", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	memory := flags.New("memory", "Memory limit").ByteSize(fs, 512*flags.Mebibyte, nil)
+	body := flags.New("body", "Body limit").ByteSize(fs, 10*flags.Megabyte, nil)
+	fs.Usage()
+
+	assert.NoError(t, fs.Parse([]string{"--body", "2MiB"}))
+	assert.Equal(t, 3*flags.Gibibyte/2, *memory)
+	assert.Equal(t, 2*flags.Mebibyte, *body)
+	assert.Equal(t, "Usage of Cache:\n  --body    size  Body limit ${CACHE_BODY} (default 10MB)\n  --memory  size  Memory limit ${CACHE_MEMORY} (default 1.5GiB)\n", writer.String())
+}
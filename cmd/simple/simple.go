@@ -23,6 +23,11 @@ func main() {
 
 	fs.Usage = flags.Usage(fs)
 
+	if err := flags.Validate(fs); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
 	_ = fs.Parse(os.Args[1:])
 
 	fmt.Printf("address=`%s`\n", *address)
@@ -0,0 +1,72 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+)
+
+type counterValue int
+
+func newCounterValue(val int, p *int) *counterValue {
+	*p = val
+
+	return (*counterValue)(p)
+}
+
+func (c *counterValue) String() string {
+	if c == nil {
+		return ""
+	}
+
+	return strconv.Itoa(int(*c))
+}
+
+func (c *counterValue) Get() any {
+	return int(*c)
+}
+
+// Set increments the counter on each occurrence of the flag, resets it with `false` or sets it with an explicit positive integer.
+func (c *counterValue) Set(value string) error {
+	switch value {
+	case "true":
+		*c++
+	case "false":
+		*c = 0
+	default:
+		count, err := parseCount(value)
+		if err != nil {
+			return err
+		}
+
+		*c = counterValue(count)
+	}
+
+	return nil
+}
+
+func (c *counterValue) IsBoolFlag() bool {
+	return true
+}
+
+func parseCount(input string) (int, error) {
+	count, err := strconv.Atoi(input)
+	if err != nil {
+		return 0, err
+	}
+
+	if count < 0 {
+		return 0, fmt.Errorf("count `%d` is negative", count)
+	}
+
+	return count, nil
+}
+
+// CounterVar binds a flag that takes no value and is incremented on each occurrence, e.g. `-v -v -v` gives 3.
+func (b Builder) CounterVar(fs *flag.FlagSet, output *int, value int, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
+	usage += ", repeat to increase"
+
+	fs.Var(newCounterValue(defaultStaticValue(b.name, value, overrides), output), flagName, usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, parseCount)
+}
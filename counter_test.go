@@ -0,0 +1,84 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounter(t *testing.T) {
+	type args struct {
+		defaultValue int
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      int
+		wantUsage string
+	}{
+		"simple": {
+			flags.New("verbose", "Verbosity"),
+			nil,
+			args{},
+			0,
+			"Usage of Counter:\n  --verbose    Verbosity ${COUNTER_VERBOSE}, repeat to increase (default 0)\n",
+		},
+		"with shorthand and args": {
+			flags.New("verbose", "Verbosity").Shorthand("v"),
+			nil,
+			args{
+				args: []string{"-v", "-v", "--verbose"},
+			},
+			3,
+			"Usage of Counter:\n  -v, --verbose    Verbosity ${COUNTER_VERBOSE}, repeat to increase (default 0)\n",
+		},
+		"with explicit value": {
+			flags.New("level", "Verbosity"),
+			nil,
+			args{
+				defaultValue: 1,
+				args:         []string{"--level=4", "--level"},
+			},
+			5,
+			"Usage of Counter:\n  --level    Verbosity ${COUNTER_LEVEL}, repeat to increase (default 1)\n",
+		},
+		"with env": {
+			flags.New("debug", "Verbosity"),
+			func() {
+				t.Setenv("COUNTER_DEBUG", "2")
+			},
+			args{
+				args: []string{"-debug"},
+			},
+			3,
+			"Usage of Counter:\n  --debug    Verbosity ${COUNTER_DEBUG}, repeat to increase (default 2)\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("Counter", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.Counter(fs, testCase.args.defaultValue, nil)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
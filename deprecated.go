@@ -0,0 +1,74 @@
+package flags
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var logger atomic.Pointer[slog.Logger]
+
+// SetLogger sets the logger used to emit deprecation warnings, slog.Default() is used when nil.
+func SetLogger(l *slog.Logger) {
+	logger.Store(l)
+}
+
+func getLogger() *slog.Logger {
+	if l := logger.Load(); l != nil {
+		return l
+	}
+
+	return slog.Default()
+}
+
+type deprecatedValue struct {
+	flag.Value
+	name        string
+	replacement string
+	once        sync.Once
+}
+
+func newDeprecatedValue(value flag.Value, name, replacement string) *deprecatedValue {
+	return &deprecatedValue{
+		Value:       value,
+		name:        name,
+		replacement: replacement,
+	}
+}
+
+func (d *deprecatedValue) Set(value string) error {
+	d.once.Do(func() {
+		getLogger().Warn("flag is deprecated", "flag", d.name, "replacement", d.replacement)
+	})
+
+	return d.Value.Set(value)
+}
+
+func (d *deprecatedValue) IsBoolFlag() bool {
+	if boolFlag, ok := d.Value.(interface{ IsBoolFlag() bool }); ok {
+		return boolFlag.IsBoolFlag()
+	}
+
+	return false
+}
+
+// lookupEnv returns the value of the environment variable, or of the first deprecated one set, warning once per FlagSet about the latter.
+func lookupEnv(fs *flag.FlagSet, envName string, deprecatedEnvs []string) (string, bool) {
+	if val, ok := os.LookupEnv(envName); ok {
+		return val, true
+	}
+
+	for _, deprecatedEnv := range deprecatedEnvs {
+		if val, ok := os.LookupEnv(deprecatedEnv); ok {
+			if _, loaded := getRegistry(fs).warnedEnvs.LoadOrStore(deprecatedEnv, struct{}{}); !loaded {
+				getLogger().Warn("environment variable is deprecated", "env", deprecatedEnv, "replacement", envName)
+			}
+
+			return val, true
+		}
+	}
+
+	return "", false
+}
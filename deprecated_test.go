@@ -0,0 +1,105 @@
+package flags_test
+
+import (
+	"flag"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated(t *testing.T) {
+	type args struct {
+		defaultValue string
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      string
+		wantUsage string
+		wantLog   string
+	}{
+		"alias": {
+			flags.New("address", "Listen address").Aliases("addr", "listen"),
+			nil,
+			args{
+				args: []string{"-addr", "localhost", "--listen", "127.0.0.1"},
+			},
+			"127.0.0.1",
+			"Usage of Deprecated:\n  --address  string  Listen address ${DEPRECATED_ADDRESS}\nDeprecated:\n  --addr    use --address instead\n  --listen  use --address instead\n",
+			"level=WARN msg=\"flag is deprecated\" flag=addr replacement=address\nlevel=WARN msg=\"flag is deprecated\" flag=listen replacement=address\n",
+		},
+		"alias with prefix": {
+			flags.New("address", "Listen address").Prefix("server").Aliases("addr"),
+			nil,
+			args{
+				args: []string{"-serverAddr", "localhost"},
+			},
+			"localhost",
+			"Usage of Deprecated:\n  --serverAddress  string  [server] Listen address ${DEPRECATED_SERVER_ADDRESS}\nDeprecated:\n  --serverAddr  use --serverAddress instead\n",
+			"level=WARN msg=\"flag is deprecated\" flag=serverAddr replacement=serverAddress\n",
+		},
+		"deprecated env": {
+			flags.New("port", "Listen port").DeprecatedEnv("LISTEN_PORT"),
+			func() {
+				t.Setenv("LISTEN_PORT", "8080")
+			},
+			args{
+				defaultValue: "1080",
+			},
+			"8080",
+			"Usage of Deprecated:\n  --port  string  Listen port ${DEPRECATED_PORT} (default \"8080\")\nDeprecated:\n  ${LISTEN_PORT}  use ${DEPRECATED_PORT} instead\n",
+			"level=WARN msg=\"environment variable is deprecated\" env=LISTEN_PORT replacement=DEPRECATED_PORT\n",
+		},
+		"env takes priority over deprecated env": {
+			flags.New("host", "Listen host").DeprecatedEnv("LISTEN_HOST"),
+			func() {
+				t.Setenv("LISTEN_HOST", "localhost")
+				t.Setenv("DEPRECATED_HOST", "127.0.0.1")
+			},
+			args{},
+			"127.0.0.1",
+			"Usage of Deprecated:\n  --host  string  Listen host ${DEPRECATED_HOST} (default \"127.0.0.1\")\nDeprecated:\n  ${LISTEN_HOST}  use ${DEPRECATED_HOST} instead\n",
+			"",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			var logs strings.Builder
+			flags.SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+					if attr.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return attr
+				},
+			})))
+			defer flags.SetLogger(nil)
+
+			fs := flag.NewFlagSet("Deprecated", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs, flags.WithDeprecated())
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.String(fs, testCase.args.defaultValue, nil)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+			assert.Equal(t, testCase.wantLog, logs.String())
+		})
+	}
+}
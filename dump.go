@@ -0,0 +1,110 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Setting is the resolved configuration of a flag.
+type Setting struct {
+	Name      string `json:"name"`
+	Env       string `json:"env,omitempty"`
+	Value     string `json:"value"`
+	Default   string `json:"default"`
+	Source    Source `json:"source"`
+	Sensitive bool   `json:"sensitive,omitempty"`
+	Changed   bool   `json:"changed"`
+}
+
+// Settings is the resolved configuration of a FlagSet.
+type Settings []Setting
+
+// Dump returns the resolved configuration of every flag of the FlagSet, with sensitive values redacted. Changed is computed before redaction.
+func Dump(fs *flag.FlagSet) Settings {
+	registry := getRegistry(fs)
+
+	var output Settings
+
+	fs.VisitAll(func(f *flag.Flag) {
+		item, ok := registry.get(f.Name)
+		if !ok {
+			source := SourceDefault
+			if isSet(fs, f.Name) {
+				source = SourceArgument
+			}
+
+			value := f.Value.String()
+
+			output = append(output, Setting{
+				Name:    f.Name,
+				Value:   value,
+				Default: f.DefValue,
+				Source:  source,
+				Changed: value != f.DefValue,
+			})
+
+			return
+		}
+
+		if item.name != f.Name {
+			return
+		}
+
+		value := f.Value.String()
+
+		output = append(output, Setting{
+			Name:      item.name,
+			Env:       item.env,
+			Value:     redact(value, item.sensitive),
+			Default:   redact(item.defaultValue, item.sensitive),
+			Source:    item.currentSource(fs),
+			Sensitive: item.sensitive,
+			Changed:   value != item.defaultValue,
+		})
+	})
+
+	return output
+}
+
+func redact(value string, sensitive bool) string {
+	if sensitive && len(value) > 0 {
+		return redacted
+	}
+
+	return value
+}
+
+// LogValue implements slog.LogValuer, with one group per flag.
+func (s Settings) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(s))
+
+	for _, setting := range s {
+		args := []any{"value", setting.Value, "source", string(setting.Source)}
+		if len(setting.Env) > 0 {
+			args = append(args, "env", setting.Env)
+		}
+
+		attrs = append(attrs, slog.Group(setting.Name, args...))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// String renders one line per flag.
+func (s Settings) String() string {
+	var builder strings.Builder
+
+	for _, setting := range s {
+		_, _ = fmt.Fprintf(&builder, "%s=%q (%s", setting.Name, setting.Value, setting.Source)
+
+		if len(setting.Env) > 0 {
+			_, _ = fmt.Fprintf(&builder, ", ${%s}", setting.Env)
+		}
+
+		builder.WriteString(")\n")
+	}
+
+	return builder.String()
+}
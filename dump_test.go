@@ -0,0 +1,51 @@
+package flags_test
+
+import (
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDump(t *testing.T) {
+	t.Setenv("DUMP_PASSWORD", "secret")
+
+	fs := flag.NewFlagSet("Dump", flag.ContinueOnError)
+
+	flags.New("address", "Listen address").Shorthand("a").Aliases("addr").String(fs, "localhost", nil)
+	flags.New("password", "Password").Sensitive().String(fs, "", nil)
+	fs.Bool("pprof", false, "Enable pprof")
+
+	assert.NoError(t, fs.Parse([]string{"-a", "127.0.0.1"}))
+
+	got := flags.Dump(fs)
+
+	assert.Equal(t, flags.Settings{
+		{Name: "address", Env: "DUMP_ADDRESS", Value: "127.0.0.1", Default: "localhost", Source: flags.SourceArgument, Changed: true},
+		{Name: "password", Env: "DUMP_PASSWORD", Value: "*****", Default: "", Source: flags.SourceEnv, Sensitive: true, Changed: true},
+		{Name: "pprof", Value: "false", Default: "false", Source: flags.SourceDefault},
+	}, got)
+
+	assert.Equal(t, "address=\"127.0.0.1\" (argument, ${DUMP_ADDRESS})\npassword=\"*****\" (env, ${DUMP_PASSWORD})\npprof=\"false\" (default)\n", got.String())
+
+	payload, err := json.Marshal(got)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"name":"address","env":"DUMP_ADDRESS","value":"127.0.0.1","default":"localhost","source":"argument","changed":true},{"name":"password","env":"DUMP_PASSWORD","value":"*****","default":"","source":"env","sensitive":true,"changed":true},{"name":"pprof","value":"false","default":"false","source":"default","changed":false}]`, string(payload))
+
+	var logs strings.Builder
+	slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	})).Info("config", "flags", got)
+
+	assert.Equal(t, "level=INFO msg=config flags.address.value=127.0.0.1 flags.address.source=argument flags.address.env=DUMP_ADDRESS flags.password.value=***** flags.password.source=env flags.password.env=DUMP_PASSWORD flags.pprof.value=false flags.pprof.source=default\n", logs.String())
+}
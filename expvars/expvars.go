@@ -0,0 +1,67 @@
+// Package expvars publishes the resolved configuration of a FlagSet to expvar.
+package expvars
+
+import (
+	"expvar"
+	"flag"
+	"fmt"
+	"sync"
+
+	"github.com/ViBiOh/flags"
+)
+
+var (
+	published = make(map[string]*flag.FlagSet)
+	mutex     sync.Mutex
+)
+
+// Name returns the expvar name under which the FlagSet is published.
+func Name(fs *flag.FlagSet) string {
+	return "flags." + fs.Name()
+}
+
+// Publish registers the configuration of the FlagSet under `flags.<name>`, as a map of flag's name to its value, with sensitive values redacted.
+// The value is computed on each read, so it reflects changes made after parsing. Publishing a FlagSet with an already published name replaces the previous one.
+// An error is returned if the name is already used by another expvar.
+func Publish(fs *flag.FlagSet) error {
+	name := Name(fs)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if _, ok := published[name]; ok {
+		published[name] = fs
+
+		return nil
+	}
+
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar `%s` is already published", name)
+	}
+
+	published[name] = fs
+
+	expvar.Publish(name, expvar.Func(func() any {
+		return values(lookup(name))
+	}))
+
+	return nil
+}
+
+func lookup(name string) *flag.FlagSet {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return published[name]
+}
+
+func values(fs *flag.FlagSet) map[string]string {
+	dump := flags.Dump(fs)
+
+	output := make(map[string]string, len(dump))
+	for _, setting := range dump {
+		output[setting.Name] = setting.Value
+	}
+
+	return output
+}
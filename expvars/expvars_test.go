@@ -0,0 +1,55 @@
+package expvars_test
+
+import (
+	"expvar"
+	"flag"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/ViBiOh/flags/expvars"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	t.Setenv("EXPVARS_PASSWORD", "secret")
+
+	fs := flag.NewFlagSet("expvars", flag.ContinueOnError)
+
+	port := flags.New("port", "Listen port").Uint(fs, 1080, nil)
+	flags.New("password", "Password").Sensitive().String(fs, "", nil)
+
+	assert.NoError(t, expvars.Publish(fs))
+	assert.NoError(t, expvars.Publish(fs))
+
+	assert.NoError(t, fs.Parse([]string{"-port", "8080"}))
+
+	variable := expvar.Get("flags.expvars")
+	assert.NotNil(t, variable)
+	assert.JSONEq(t, `{"password":"*****","port":"8080"}`, variable.String())
+
+	*port = 9090
+	assert.JSONEq(t, `{"password":"*****","port":"9090"}`, variable.String())
+}
+
+func TestPublishReplace(t *testing.T) {
+	first := flag.NewFlagSet("replace", flag.ContinueOnError)
+	flags.New("port", "Listen port").Uint(first, 1080, nil)
+
+	assert.NoError(t, expvars.Publish(first))
+
+	second := flag.NewFlagSet("replace", flag.ContinueOnError)
+	flags.New("port", "Listen port").Uint(second, 8080, nil)
+
+	assert.NoError(t, expvars.Publish(second))
+	assert.JSONEq(t, `{"port":"8080"}`, expvar.Get("flags.replace").String())
+}
+
+func TestPublishConflict(t *testing.T) {
+	if expvar.Get("flags.conflict") == nil {
+		expvar.NewString("flags.conflict")
+	}
+
+	fs := flag.NewFlagSet("conflict", flag.ContinueOnError)
+
+	assert.EqualError(t, expvars.Publish(fs), "expvar `flags.conflict` is already published")
+}
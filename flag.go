@@ -3,7 +3,6 @@ package flags
 import (
 	"flag"
 	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -11,199 +10,229 @@ import (
 
 // String creates a string flag.
 func String(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, value string, overrides []Override) *string {
-	output := new(string)
-
-	StringVar(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").String(fs, value, overrides)
 }
 
 // StringVar bind a string flag.
 func StringVar(fs *flag.FlagSet, output *string, prefix, docPrefix, name, shorthand, label, env, value string, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").StringVar(fs, output, value, overrides)
+}
 
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, func(input string) (string, error) {
+func (b Builder) StringVar(fs *flag.FlagSet, output *string, value string, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
+
+	fs.StringVar(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (string, error) {
 		return input, nil
 	})
-
-	if len(shorthand) > 0 {
-		fs.StringVar(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
-
-	fs.StringVar(output, firstLowerCase(flagName), initialValue, usage)
 }
 
 // Int creates an int flag.
 func Int(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value int, overrides []Override) *int {
-	output := new(int)
-
-	IntVar(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int(fs, value, overrides)
 }
 
 // IntVar bind an int flag.
 func IntVar(fs *flag.FlagSet, output *int, prefix, docPrefix, name, shorthand, label, env string, value int, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").IntVar(fs, output, value, overrides)
+}
+
+func (b Builder) IntVar(fs *flag.FlagSet, output *int, value int, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
 
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, func(input string) (int, error) {
-		intVal, err := strconv.ParseInt(input, 10, 32)
+	fs.IntVar(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (int, error) {
+		intVal, err := strconv.ParseInt(input, 0, strconv.IntSize)
 		return int(intVal), err
 	})
-
-	if len(shorthand) > 0 {
-		fs.IntVar(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
-
-	fs.IntVar(output, firstLowerCase(flagName), initialValue, usage)
 }
 
 // Int64 creates an int64 flag.
 func Int64(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value int64, overrides []Override) *int64 {
-	output := new(int64)
-
-	Int64Var(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int64(fs, value, overrides)
 }
 
 // Int64Var bind an int64 flag.
 func Int64Var(fs *flag.FlagSet, output *int64, prefix, docPrefix, name, shorthand, label, env string, value int64, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
-
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, func(input string) (int64, error) {
-		return strconv.ParseInt(input, 10, 64)
-	})
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int64Var(fs, output, value, overrides)
+}
 
-	if len(shorthand) > 0 {
-		fs.Int64Var(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
+func (b Builder) Int64Var(fs *flag.FlagSet, output *int64, value int64, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
 
-	fs.Int64Var(output, firstLowerCase(flagName), initialValue, usage)
+	fs.Int64Var(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (int64, error) {
+		return strconv.ParseInt(input, 0, 64)
+	})
 }
 
 // Uint creates an uint flag.
 func Uint(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value uint, overrides []Override) *uint {
-	output := new(uint)
-
-	UintVar(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint(fs, value, overrides)
 }
 
 // UintVar bind an uint flag.
 func UintVar(fs *flag.FlagSet, output *uint, prefix, docPrefix, name, shorthand, label, env string, value uint, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").UintVar(fs, output, value, overrides)
+}
+
+func (b Builder) UintVar(fs *flag.FlagSet, output *uint, value uint, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
 
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, func(input string) (uint, error) {
-		intVal, err := strconv.ParseUint(input, 10, 32)
+	fs.UintVar(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (uint, error) {
+		intVal, err := strconv.ParseUint(input, 0, strconv.IntSize)
 		return uint(intVal), err
 	})
-
-	if len(shorthand) > 0 {
-		fs.UintVar(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
-
-	fs.UintVar(output, firstLowerCase(flagName), initialValue, usage)
 }
 
 // Uint64 creates an uint64 flag.
 func Uint64(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value uint64, overrides []Override) *uint64 {
-	output := new(uint64)
-
-	Uint64Var(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint64(fs, value, overrides)
 }
 
 // Uint64Var binds an uint64 flag.
 func Uint64Var(fs *flag.FlagSet, output *uint64, prefix, docPrefix, name, shorthand, label, env string, value uint64, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
-
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, func(input string) (uint64, error) {
-		return strconv.ParseUint(input, 10, 64)
-	})
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint64Var(fs, output, value, overrides)
+}
 
-	if len(shorthand) > 0 {
-		fs.Uint64Var(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
+func (b Builder) Uint64Var(fs *flag.FlagSet, output *uint64, value uint64, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
 
-	fs.Uint64Var(output, firstLowerCase(flagName), initialValue, usage)
+	fs.Uint64Var(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (uint64, error) {
+		return strconv.ParseUint(input, 0, 64)
+	})
 }
 
 // Float64 creates a float64 flag.
 func Float64(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value float64, overrides []Override) *float64 {
-	output := new(float64)
-
-	Float64Var(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Float64(fs, value, overrides)
 }
 
 // Float64Var binds a float64 flag.
 func Float64Var(fs *flag.FlagSet, output *float64, prefix, docPrefix, name, shorthand, label, env string, value float64, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Float64Var(fs, output, value, overrides)
+}
+
+func (b Builder) Float64Var(fs *flag.FlagSet, output *float64, value float64, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
 
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, func(input string) (float64, error) {
+	fs.Float64Var(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (float64, error) {
 		return strconv.ParseFloat(input, 64)
 	})
-
-	if len(shorthand) > 0 {
-		fs.Float64Var(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
-
-	fs.Float64Var(output, firstLowerCase(flagName), initialValue, usage)
 }
 
 // Bool creates a bool flag.
 func Bool(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value bool, overrides []Override) *bool {
-	output := new(bool)
-
-	BoolVar(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Bool(fs, value, overrides)
 }
 
 // BoolVar binds a bool flag.
 func BoolVar(fs *flag.FlagSet, output *bool, prefix, docPrefix, name, shorthand, label, env string, value bool, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
-
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, strconv.ParseBool)
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").BoolVar(fs, output, value, overrides)
+}
 
-	if len(shorthand) > 0 {
-		fs.BoolVar(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
+func (b Builder) BoolVar(fs *flag.FlagSet, output *bool, value bool, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
 
-	fs.BoolVar(output, firstLowerCase(flagName), initialValue, usage)
+	fs.BoolVar(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, strconv.ParseBool)
 }
 
 // Duration creates a duration flag.
 func Duration(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value time.Duration, overrides []Override) *time.Duration {
-	output := new(time.Duration)
-
-	DurationVar(fs, output, prefix, docPrefix, name, shorthand, label, env, value, overrides)
-
-	return output
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Duration(fs, value, overrides)
 }
 
 // DurationVar binds a duration flag.
 func DurationVar(fs *flag.FlagSet, output *time.Duration, prefix, docPrefix, name, shorthand, label, env string, value time.Duration, overrides []Override) {
-	flagName, envName, usage := computeDescription(fs, prefix, docPrefix, name, label, env)
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").DurationVar(fs, output, value, overrides)
+}
 
-	initialValue := defaultValue(defaultStaticValue(name, value, overrides), envName, time.ParseDuration)
+func (b Builder) DurationVar(fs *flag.FlagSet, output *time.Duration, value time.Duration, overrides []Override) {
+	flagName, envName, usage := b.computeDescription(fs)
 
-	if len(shorthand) > 0 {
-		fs.DurationVar(output, firstLowerCase(prefix+firstUpperCase(shorthand)), initialValue, usage)
-	}
+	fs.DurationVar(output, flagName, defaultStaticValue(b.name, value, overrides), usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, time.ParseDuration)
+}
+
+func (b Builder) computeDescription(fs *flag.FlagSet) (string, string, string) {
+	flagName, envName := getNameAndEnv(fs, firstUpperCase(b.prefix), b.name, b.env)
+	usage := formatLabel(b.prefix, b.docPrefix, b.label, envName)
+
+	return firstLowerCase(flagName), envName, usage
+}
 
-	fs.DurationVar(output, firstLowerCase(flagName), initialValue, usage)
+func bind[T any](b Builder, fs *flag.FlagSet, flagName, envName, usage string, output *T, overrides []Override, parse func(string) (T, error)) {
+	bindEnv(b, fs, flagName, envName, usage, output, overrides, func() (string, T, bool, error) {
+		val, ok := lookupEnv(fs, envName, b.deprecatedEnvs)
+		if !ok {
+			var zero T
+			return "", zero, false, nil
+		}
+
+		parsed, err := parse(val)
+
+		return val, parsed, true, err
+	})
 }
 
-func computeDescription(fs *flag.FlagSet, prefix, docPrefix, name, label, env string) (string, string, string) {
-	flagName, envName := getNameAndEnv(fs, firstUpperCase(prefix), name, env)
-	usage := formatLabel(prefix, docPrefix, label, envName)
+// bindEnv registers the flag's names and metadata, and applies the value found by lookup in the environment.
+func bindEnv[T any](b Builder, fs *flag.FlagSet, flagName, envName, usage string, output *T, overrides []Override, lookup func() (string, T, bool, error)) {
+	f := fs.Lookup(flagName)
+
+	item := &entry{
+		name:           flagName,
+		env:            envName,
+		group:          b.group(),
+		typeName:       b.typeName,
+		defaultValue:   f.DefValue,
+		source:         SourceDefault,
+		deprecatedEnvs: b.deprecatedEnvs,
+		hidden:         b.hidden,
+		sensitive:      b.sensitive,
+	}
+
+	if hasOverride(b.name, overrides) {
+		item.source = SourceOverride
+	}
+
+	if b.defaultErr != nil {
+		item.defaultErr = fmt.Errorf("default of flag `%s`: %w", flagName, b.defaultErr)
+	}
+
+	val, parsed, ok, err := lookup()
+	if ok {
+		item.envValue = val
+		if b.redact != nil {
+			item.envValue = b.redact(val)
+		}
+		item.envSet = true
+	}
+
+	if err != nil {
+		item.err = fmt.Errorf("env `%s` of flag `%s`: %w", envName, flagName, err)
+	} else if ok {
+		*output = parsed
+		f.DefValue = f.Value.String()
+		item.source = SourceEnv
+	}
+
+	if len(b.shorthand) > 0 {
+		item.shorthand = firstLowerCase(b.prefix + firstUpperCase(b.shorthand))
+		fs.Var(f.Value, item.shorthand, usage)
+	}
 
-	return flagName, envName, usage
+	for _, alias := range b.aliases {
+		aliasName := firstLowerCase(b.prefix + firstUpperCase(alias))
+		item.aliases = append(item.aliases, aliasName)
+
+		fs.Var(newDeprecatedValue(f.Value, aliasName, flagName), aliasName, usage)
+	}
+
+	getRegistry(fs).add(item)
 }
 
 func getNameAndEnv(fs *flag.FlagSet, prefix, name, env string) (string, string) {
@@ -216,12 +245,21 @@ func getNameAndEnv(fs *flag.FlagSet, prefix, name, env string) (string, string)
 	return name, env
 }
 
-func formatLabel(prefix, docPrefix, label, envName string) string {
-	docPrefixValue := prefix
+func (b Builder) group() string {
+	return getDocPrefix(b.prefix, b.docPrefix)
+}
+
+func getDocPrefix(prefix, docPrefix string) string {
 	if len(prefix) == 0 {
-		docPrefixValue = docPrefix
+		return docPrefix
 	}
 
+	return prefix
+}
+
+func formatLabel(prefix, docPrefix, label, envName string) string {
+	docPrefixValue := getDocPrefix(prefix, docPrefix)
+
 	builder := strings.Builder{}
 
 	if len(docPrefixValue) != 0 {
@@ -231,14 +269,3 @@ func formatLabel(prefix, docPrefix, label, envName string) string {
 
 	return builder.String()
 }
-
-func defaultValue[T any](value T, envName string, parse func(string) (T, error)) T {
-	if val, ok := os.LookupEnv(envName); ok {
-		parsed, err := parse(val)
-		if err == nil {
-			return parsed
-		}
-	}
-
-	return value
-}
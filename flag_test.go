@@ -186,6 +186,24 @@ func TestInt(t *testing.T) {
 			30,
 			"Usage of Int:\n  -studentA, --studentAge  int  [student] Age of people ${USE_THIS_ENV} (default 25)\n",
 		},
+		"env wider than 32 bits": {
+			flags.New("offset", "Offset").Env("INT_OFFSET"),
+			func() {
+				t.Setenv("INT_OFFSET", "4294967296")
+			},
+			args{},
+			4294967296,
+			"Usage of Int:\n  --offset  int  Offset ${INT_OFFSET} (default 4294967296)\n",
+		},
+		"env with base prefix": {
+			flags.New("mask", "Mask").Env("INT_MASK"),
+			func() {
+				t.Setenv("INT_MASK", "0x1_00")
+			},
+			args{},
+			256,
+			"Usage of Int:\n  --mask  int  Mask ${INT_MASK} (default 256)\n",
+		},
 	}
 
 	for intention, testCase := range cases {
@@ -288,6 +306,15 @@ func TestUInt(t *testing.T) {
 			30,
 			"Usage of UInt:\n  -studentA, --studentAge  uint  [student] Age of people ${USE_THIS_ENV} (default 25)\n",
 		},
+		"env wider than 32 bits with base prefix": {
+			flags.New("size", "Size").Env("UINT_SIZE"),
+			func() {
+				t.Setenv("UINT_SIZE", "0o100000000000")
+			},
+			args{},
+			8589934592,
+			"Usage of UInt:\n  --size  uint  Size ${UINT_SIZE} (default 8589934592)\n",
+		},
 	}
 
 	for intention, testCase := range cases {
@@ -515,3 +542,57 @@ func TestDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestHidden(t *testing.T) {
+	cases := map[string]struct {
+		options   []flags.UsageOption
+		args      []string
+		want      string
+		wantUsage string
+	}{
+		"hidden": {
+			nil,
+			[]string{"--debugAddress", "localhost:6060"},
+			"localhost:6060",
+			"Usage of Hidden:\n  --address  string  Listen address ${HIDDEN_ADDRESS}\n",
+		},
+		"with hidden": {
+			[]flags.UsageOption{flags.WithHidden()},
+			nil,
+			"",
+			"Usage of Hidden:\n  --address       string  Listen address ${HIDDEN_ADDRESS}\n  --debugAddress  string  Debug listen address ${HIDDEN_DEBUG_ADDRESS}\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("Hidden", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs, testCase.options...)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			flags.New("address", "Listen address").String(fs, "", nil)
+			got := flags.New("debugAddress", "Debug listen address").Hidden().String(fs, "", nil)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv("VALIDATE_PORT", "http")
+	t.Setenv("VALIDATE_TIMEOUT", "1m")
+
+	fs := flag.NewFlagSet("Validate", flag.ContinueOnError)
+
+	port := flags.New("port", "Listen port").Uint(fs, 1080, nil)
+	timeout := flags.New("timeout", "Timeout").Duration(fs, time.Second, nil)
+
+	assert.Equal(t, uint(1080), *port)
+	assert.Equal(t, time.Minute, *timeout)
+	assert.EqualError(t, flags.Validate(fs), "env `VALIDATE_PORT` of flag `port`: strconv.ParseUint: parsing \"http\": invalid syntax")
+}
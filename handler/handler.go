@@ -0,0 +1,82 @@
+// Package handler exposes the resolved configuration of a FlagSet over HTTP, e.g. on a `/debug/config` route.
+package handler
+
+import (
+	"encoding/json"
+	"flag"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/ViBiOh/flags"
+)
+
+var page = template.Must(template.New("config").Parse(`<!doctype html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <title>{{ .Name }} configuration</title>
+    <style>
+      table { border-collapse: collapse; font-family: monospace; }
+      th, td { border: 1px solid #ccc; padding: 0.25rem 0.5rem; text-align: left; }
+      tr.changed { background-color: #fff3c4; }
+    </style>
+  </head>
+  <body>
+    <h1>{{ .Name }} configuration</h1>
+    <table>
+      <thead>
+        <tr><th>Name</th><th>Env</th><th>Value</th><th>Default</th><th>Source</th></tr>
+      </thead>
+      <tbody>
+        {{- range .Settings }}
+        <tr{{ if .Changed }} class="changed"{{ end }}><td>{{ .Name }}</td><td>{{ .Env }}</td><td>{{ .Value }}</td><td>{{ .Default }}</td><td>{{ .Source }}</td></tr>
+        {{- end }}
+      </tbody>
+    </table>
+  </body>
+</html>
+`))
+
+type content struct {
+	Name     string
+	Settings flags.Settings
+}
+
+// New creates a handler serving the resolved configuration of the FlagSet, as JSON when requested by `Accept` header or `format=json` query, as HTML otherwise.
+func New(fs *flag.FlagSet) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		settings := flags.Dump(fs)
+
+		w.Header().Set("Cache-Control", "no-cache")
+
+		if wantJSON(r) {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+			if err := json.NewEncoder(w).Encode(settings); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if err := page.Execute(w, content{Name: fs.Name(), Settings: settings}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func wantJSON(r *http.Request) bool {
+	if r.URL.Query().Get("format") == "json" {
+		return true
+	}
+
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
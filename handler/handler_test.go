@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/ViBiOh/flags/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Setenv("HANDLER_PASSWORD", "secret")
+
+	fs := flag.NewFlagSet("Handler", flag.ContinueOnError)
+
+	flags.New("address", "Listen address").String(fs, "localhost", nil)
+	flags.New("port", "Listen port").Uint(fs, 1080, nil)
+	flags.New("password", "Password").Sensitive().String(fs, "", nil)
+	flags.New("token", "Token").Sensitive().String(fs, "default", nil)
+
+	assert.NoError(t, fs.Parse([]string{"-port", "8080", "-token", "rotated"}))
+
+	cases := map[string]struct {
+		request         *http.Request
+		wantStatus      int
+		wantContentType string
+		want            []string
+	}{
+		"json": {
+			httptest.NewRequest(http.MethodGet, "/debug/config?format=json", nil),
+			http.StatusOK,
+			"application/json; charset=utf-8",
+			[]string{`[{"name":"address","env":"HANDLER_ADDRESS","value":"localhost","default":"localhost","source":"default","changed":false},{"name":"password","env":"HANDLER_PASSWORD","value":"*****","default":"","source":"env","sensitive":true,"changed":true},{"name":"port","env":"HANDLER_PORT","value":"8080","default":"1080","source":"argument","changed":true},{"name":"token","env":"HANDLER_TOKEN","value":"*****","default":"*****","source":"argument","sensitive":true,"changed":true}]`},
+		},
+		"html": {
+			httptest.NewRequest(http.MethodGet, "/debug/config", nil),
+			http.StatusOK,
+			"text/html; charset=utf-8",
+			[]string{
+				"<title>Handler configuration</title>",
+				`<tr><td>address</td><td>HANDLER_ADDRESS</td><td>localhost</td><td>localhost</td><td>default</td></tr>`,
+				`<tr class="changed"><td>port</td><td>HANDLER_PORT</td><td>8080</td><td>1080</td><td>argument</td></tr>`,
+				`<tr class="changed"><td>token</td><td>HANDLER_TOKEN</td><td>*****</td><td>*****</td><td>argument</td></tr>`,
+			},
+		},
+		"method not allowed": {
+			httptest.NewRequest(http.MethodPost, "/debug/config", nil),
+			http.StatusMethodNotAllowed,
+			"",
+			nil,
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			writer := httptest.NewRecorder()
+			handler.New(fs).ServeHTTP(writer, testCase.request)
+
+			assert.Equal(t, testCase.wantStatus, writer.Code)
+			assert.Equal(t, testCase.wantContentType, writer.Header().Get("Content-Type"))
+
+			for _, want := range testCase.want {
+				assert.Contains(t, writer.Body.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"flag"
+	"log/slog"
+	"strconv"
+)
+
+type levelValue struct {
+	output *slog.LevelVar
+}
+
+func newLevelValue(val slog.Level, p *slog.LevelVar) *levelValue {
+	p.Set(val)
+
+	return &levelValue{output: p}
+}
+
+func (l *levelValue) String() string {
+	if l == nil || l.output == nil {
+		return ""
+	}
+
+	return l.output.Level().String()
+}
+
+func (l *levelValue) Get() any {
+	return l.output.Level()
+}
+
+func (l *levelValue) Set(input string) error {
+	level, err := parseLevel(input)
+	if err != nil {
+		return err
+	}
+
+	l.output.Set(level)
+
+	return nil
+}
+
+// parseLevel accepts a level name with an optional offset, e.g. `info+2`, or its numeric value.
+func parseLevel(input string) (slog.Level, error) {
+	var level slog.Level
+
+	err := level.UnmarshalText([]byte(input))
+	if err == nil {
+		return level, nil
+	}
+
+	if value, atoiErr := strconv.Atoi(input); atoiErr == nil {
+		return slog.Level(value), nil
+	}
+
+	return level, err
+}
+
+// SlogLevelVar binds a log level flag to a slog.LevelVar, that can still be changed at runtime.
+func (b Builder) SlogLevelVar(fs *flag.FlagSet, output *slog.LevelVar, value slog.Level, overrides []Override) {
+	b = b.typed("level")
+
+	flagName, envName, usage := b.computeDescription(fs)
+	usage += ", one of DEBUG, INFO, WARN or ERROR with an optional offset like INFO+2, or a number"
+
+	target := newLevelValue(defaultStaticValue(b.name, value, overrides), output)
+
+	fs.Var(target, flagName, usage)
+	bindEnv(b, fs, flagName, envName, usage, new(slog.Level), overrides, func() (string, slog.Level, bool, error) {
+		val, ok := lookupEnv(fs, envName, b.deprecatedEnvs)
+		if !ok {
+			return "", 0, false, nil
+		}
+
+		err := target.Set(val)
+
+		return val, output.Level(), true, err
+	})
+}
@@ -0,0 +1,100 @@
+package flags_test
+
+import (
+	"flag"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlogLevel(t *testing.T) {
+	cases := map[string]struct {
+		preTest func(*testing.T)
+		args    []string
+		want    slog.Level
+		wantErr string
+	}{
+		"default": {
+			nil,
+			nil,
+			slog.LevelInfo,
+			"",
+		},
+		"name": {
+			nil,
+			[]string{"--logLevel", "debug"},
+			slog.LevelDebug,
+			"",
+		},
+		"offset": {
+			nil,
+			[]string{"--logLevel", "info+2"},
+			slog.LevelInfo + 2,
+			"",
+		},
+		"number": {
+			nil,
+			[]string{"--logLevel", "-8"},
+			slog.Level(-8),
+			"",
+		},
+		"env": {
+			func(t *testing.T) {
+				t.Setenv("LOGGER_LOG_LEVEL", "WARN")
+			},
+			nil,
+			slog.LevelWarn,
+			"",
+		},
+		"invalid": {
+			nil,
+			[]string{"--logLevel", "verbose"},
+			slog.LevelInfo,
+			"invalid value \"verbose\" for flag -logLevel: slog: level string \"verbose\": unknown name",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			if testCase.preTest != nil {
+				testCase.preTest(t)
+			}
+
+			fs := flag.NewFlagSet("Logger", flag.ContinueOnError)
+			fs.SetOutput(&strings.Builder{})
+
+			got := flags.New("logLevel", "Log level").SlogLevel(fs, slog.LevelInfo, nil)
+
+			err := fs.Parse(testCase.args)
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, got.Level())
+		})
+	}
+}
+
+func TestSlogLevelUsage(t *testing.T) {
+	t.Setenv("LEVEL_LOG_LEVEL", "error")
+
+	fs := flag.NewFlagSet("Level", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	level := flags.New("logLevel", "Log level").SlogLevel(fs, slog.LevelInfo, nil)
+	fs.Usage()
+
+	assert.Equal(t, slog.LevelError, level.Level())
+	assert.Equal(t, "Usage of Level:\n  --logLevel  level  Log level ${LEVEL_LOG_LEVEL}, one of DEBUG, INFO, WARN or ERROR with an optional offset like INFO+2, or a number (default ERROR)\n", writer.String())
+
+	level.Set(slog.LevelDebug)
+	assert.Equal(t, "DEBUG", fs.Lookup("logLevel").Value.String())
+}
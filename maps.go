@@ -0,0 +1,185 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DuplicatePolicy defines how a key given twice to a map flag is handled.
+type DuplicatePolicy int
+
+const (
+	// DuplicateLast keeps the last value given for a key.
+	DuplicateLast DuplicatePolicy = iota
+	// DuplicateFirst keeps the first value given for a key.
+	DuplicateFirst
+	// DuplicateError rejects a key given twice.
+	DuplicateError
+)
+
+type mapValue[K comparable, V any] struct {
+	values     *map[K]V
+	parseKey   func(string) (K, error)
+	parseValue func(string) (V, error)
+	quote      func(string) string
+	split      func(string) ([]string, error)
+	separator  string
+	duplicates DuplicatePolicy
+	edited     bool
+}
+
+func newMapValue[K comparable, V any](val map[K]V, p *map[K]V, separator string, duplicates DuplicatePolicy, parseKey func(string) (K, error), parseValue func(string) (V, error)) *mapValue[K, V] {
+	*p = val
+
+	return &mapValue[K, V]{
+		values:     p,
+		parseKey:   parseKey,
+		parseValue: parseValue,
+		separator:  separator,
+		duplicates: duplicates,
+	}
+}
+
+func (i *mapValue[K, V]) String() string {
+	if i == nil || i.values == nil || len(*i.values) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(*i.values))
+	for key, value := range *i.values {
+		pair := fmt.Sprintf("%v%s%v", key, i.separator, value)
+		if i.quote != nil {
+			pair = i.quote(pair)
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	slices.Sort(pairs)
+
+	return "[" + strings.Join(pairs, listSeparator) + "]"
+}
+
+func (i *mapValue[K, V]) Get() any {
+	return *i.values
+}
+
+func (i *mapValue[K, V]) Set(value string) error {
+	if !i.edited {
+		i.edited = true
+
+		*i.values = make(map[K]V)
+	}
+
+	if i.split == nil {
+		return i.put(*i.values, value)
+	}
+
+	pairs, err := i.split(value)
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range pairs {
+		if err := i.put(*i.values, pair); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *mapValue[K, V]) put(output map[K]V, pair string) error {
+	rawKey, rawValue, ok := strings.Cut(pair, i.separator)
+	if !ok {
+		return fmt.Errorf("`%s` is not in `key%svalue` format", pair, i.separator)
+	}
+
+	key, err := i.parseKey(rawKey)
+	if err != nil {
+		return fmt.Errorf("parse key `%s`: %w", rawKey, err)
+	}
+
+	value, err := i.parseValue(rawValue)
+	if err != nil {
+		return fmt.Errorf("parse value `%s`: %w", rawValue, err)
+	}
+
+	if _, exists := output[key]; exists {
+		switch i.duplicates {
+		case DuplicateFirst:
+			return nil
+		case DuplicateError:
+			return fmt.Errorf("duplicate key `%s`", rawKey)
+		}
+	}
+
+	output[key] = value
+
+	return nil
+}
+
+// Map creates a map flag, with keys and values parsed by the given functions.
+func Map[K comparable, V any](b Builder, fs *flag.FlagSet, value map[K]V, overrides []Override, parseKey func(string) (K, error), parseValue func(string) (V, error)) *map[K]V {
+	output := new(map[K]V)
+
+	MapVar(b, fs, output, value, overrides, parseKey, parseValue)
+
+	return output
+}
+
+// MapVar binds a map flag, with keys and values parsed by the given functions.
+func MapVar[K comparable, V any](b Builder, fs *flag.FlagSet, output *map[K]V, value map[K]V, overrides []Override, parseKey func(string) (K, error), parseValue func(string) (V, error)) {
+	mapVar(b, fs, output, value, overrides, "map", parseKey, parseValue)
+}
+
+func (b Builder) StringMapVar(fs *flag.FlagSet, output *map[string]string, value map[string]string, overrides []Override) {
+	identity := func(input string) (string, error) {
+		return input, nil
+	}
+
+	mapVar(b, fs, output, value, overrides, "string map", identity, identity)
+}
+
+func mapVar[K comparable, V any](b Builder, fs *flag.FlagSet, output *map[K]V, value map[K]V, overrides []Override, typeName string, parseKey func(string) (K, error), parseValue func(string) (V, error)) {
+	flagName, envName, usage := b.computeDescription(fs)
+	usage += fmt.Sprintf(", as a `%s` of %q, environment variable separated by %q", typeName, "key"+b.kvSeparator+"value", b.envSeparator)
+
+	target := newMapValue(defaultStaticValue(b.name, value, overrides), output, b.kvSeparator, b.duplicates, parseKey, parseValue)
+
+	if b.quoted {
+		usage += ", with double quotes and backslash escaping"
+
+		// a pair is the element split by the environment separator, so it's quoted as a whole, e.g. `"key=a,b"`
+		target.quote = func(pair string) string {
+			return quote(pair, b.envSeparator, listSeparator)
+		}
+
+		target.split = func(input string) ([]string, error) {
+			return splitQuoted(input, b.envSeparator)
+		}
+	}
+
+	fs.Var(target, flagName, usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (map[K]V, error) {
+		items := make(map[K]V)
+		if len(input) == 0 {
+			return items, nil
+		}
+
+		pairs, err := b.splitEnv(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pair := range pairs {
+			if err := target.put(items, pair); err != nil {
+				return nil, err
+			}
+		}
+
+		return items, nil
+	})
+}
@@ -0,0 +1,159 @@
+package flags_test
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringMap(t *testing.T) {
+	type args struct {
+		defaultValue map[string]string
+		overrides    []flags.Override
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      map[string]string
+		wantUsage string
+		wantErr   string
+	}{
+		"simple": {
+			flags.New("labels", "Labels"),
+			nil,
+			args{},
+			nil,
+			"Usage of StringMap:\n  --labels  string map  Labels ${STRING_MAP_LABELS}, as a string map of \"key=value\", environment variable separated by \",\"\n",
+			"",
+		},
+		"with default value": {
+			flags.New("labels", "Labels"),
+			nil,
+			args{
+				defaultValue: map[string]string{"team": "core", "env": "dev"},
+			},
+			map[string]string{"team": "core", "env": "dev"},
+			"Usage of StringMap:\n  --labels  string map  Labels ${STRING_MAP_LABELS}, as a string map of \"key=value\", environment variable separated by \",\" (default [env=dev, team=core])\n",
+			"",
+		},
+		"with read from environment variable": {
+			flags.New("headers", "Headers").KeyValueSeparator(":").EnvSeparator("|"),
+			func() {
+				t.Setenv("STRING_MAP_HEADERS", "X-User:bob|Authorization:Basic a2V5")
+			},
+			args{
+				defaultValue: map[string]string{"X-User": "alice"},
+			},
+			map[string]string{"X-User": "bob", "Authorization": "Basic a2V5"},
+			"Usage of StringMap:\n  --headers  string map  Headers ${STRING_MAP_HEADERS}, as a string map of \"key:value\", environment variable separated by \"|\" (default [Authorization:Basic a2V5, X-User:bob])\n",
+			"",
+		},
+		"with quoted": {
+			flags.New("selector", "Selector").Quoted(),
+			func() {
+				t.Setenv("STRING_MAP_SELECTOR", `"app=api,web",tier=back`)
+			},
+			args{
+				defaultValue: map[string]string{"app": "api"},
+				args:         []string{"--selector", `"app=api,web"`, "--selector", `"zone=eu,us",tier=front`},
+			},
+			map[string]string{"app": "api,web", "zone": "eu,us", "tier": "front"},
+			"Usage of StringMap:\n  --selector  string map  Selector ${STRING_MAP_SELECTOR}, as a string map of \"key=value\", environment variable separated by \",\", with double quotes and backslash escaping (default [\"app=api,web\", tier=back])\n",
+			"",
+		},
+		"with args": {
+			flags.New("label", "Label").Shorthand("l"),
+			nil,
+			args{
+				defaultValue: map[string]string{"team": "core"},
+				args:         []string{"--label", "env=prod", "-l", "team=infra", "-l", "env=dev"},
+			},
+			map[string]string{"team": "infra", "env": "dev"},
+			"Usage of StringMap:\n  -l, --label  string map  Label ${STRING_MAP_LABEL}, as a string map of \"key=value\", environment variable separated by \",\" (default [team=core])\n",
+			"",
+		},
+		"with duplicate first": {
+			flags.New("tag", "Tag").DuplicateKeys(flags.DuplicateFirst),
+			nil,
+			args{
+				args: []string{"--tag", "env=prod", "--tag", "env=dev"},
+			},
+			map[string]string{"env": "prod"},
+			"Usage of StringMap:\n  --tag  string map  Tag ${STRING_MAP_TAG}, as a string map of \"key=value\", environment variable separated by \",\"\n",
+			"",
+		},
+		"with duplicate error": {
+			flags.New("tag", "Tag").DuplicateKeys(flags.DuplicateError),
+			nil,
+			args{
+				args: []string{"--tag", "env=prod", "--tag", "env=dev"},
+			},
+			map[string]string{"env": "prod"},
+			"Usage of StringMap:\n  --tag  string map  Tag ${STRING_MAP_TAG}, as a string map of \"key=value\", environment variable separated by \",\"\n",
+			"invalid value \"env=dev\" for flag -tag: duplicate key `env`",
+		},
+		"with invalid pair": {
+			flags.New("tag", "Tag"),
+			nil,
+			args{
+				args: []string{"--tag", "env"},
+			},
+			map[string]string{},
+			"Usage of StringMap:\n  --tag  string map  Tag ${STRING_MAP_TAG}, as a string map of \"key=value\", environment variable separated by \",\"\n",
+			"invalid value \"env\" for flag -tag: `env` is not in `key=value` format",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("StringMap", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.StringMap(fs, testCase.args.defaultValue, testCase.args.overrides)
+			fs.Usage()
+			assert.Equal(t, testCase.wantUsage, writer.String())
+
+			err := fs.Parse(testCase.args.args)
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, *got)
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	t.Setenv("MAP_TIMEOUTS", "1=1s,2=1m")
+
+	fs := flag.NewFlagSet("Map", flag.ContinueOnError)
+
+	parseKey := func(input string) (int, error) {
+		return strconv.Atoi(input)
+	}
+
+	got := flags.Map(flags.New("timeouts", "Timeout per attempt"), fs, nil, nil, parseKey, time.ParseDuration)
+
+	assert.Equal(t, map[int]time.Duration{1: time.Second, 2: time.Minute}, *got)
+	assert.Equal(t, "[1=1s, 2=1m0s]", fs.Lookup("timeouts").DefValue)
+
+	assert.NoError(t, fs.Parse([]string{"-timeouts", "3=1h"}))
+	assert.Equal(t, map[int]time.Duration{3: time.Hour}, *got)
+}
@@ -0,0 +1,121 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+func formatAddr(value netip.Addr) string {
+	if !value.IsValid() {
+		return ""
+	}
+
+	return value.String()
+}
+
+func parsePrefix(input string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(input)
+	if err != nil {
+		return prefix, err
+	}
+
+	return prefix.Masked(), nil
+}
+
+func formatPrefix(value netip.Prefix) string {
+	if !value.IsValid() {
+		return ""
+	}
+
+	return value.String()
+}
+
+func formatAddrPort(value netip.AddrPort) string {
+	if !value.IsValid() {
+		return ""
+	}
+
+	return value.String()
+}
+
+// parseHostPort accepts an IP or a hostname with a numeric port. The host can be empty, e.g. `:8080` to listen on all interfaces.
+func parseHostPort(input string) (string, error) {
+	host, port, err := net.SplitHostPort(input)
+	if err != nil {
+		return "", err
+	}
+
+	if len(host) > 0 && !isHostname(host) {
+		if _, err := netip.ParseAddr(host); err != nil {
+			return "", fmt.Errorf("invalid host `%s`", host)
+		}
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return "", fmt.Errorf("invalid port `%s`", port)
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
+
+// isHostname checks the syntax of a hostname: dot-separated labels of letters, digits, hyphens and underscores, not starting or ending with an hyphen.
+func isHostname(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	if len(host) == 0 || len(host) > 253 {
+		return false
+	}
+
+	for label := range strings.SplitSeq(host, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+
+		for _, char := range label {
+			if !(char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' || char >= '0' && char <= '9' || char == '-' || char == '_') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func formatHostPort(value string) string {
+	return value
+}
+
+func (b Builder) IPVar(fs *flag.FlagSet, output *netip.Addr, value netip.Addr, overrides []Override) {
+	valueVar(b.typed("ip"), fs, output, value, overrides, netip.ParseAddr, formatAddr)
+}
+
+func (b Builder) IPPrefixVar(fs *flag.FlagSet, output *netip.Prefix, value netip.Prefix, overrides []Override) {
+	valueVar(b.typed("cidr"), fs, output, value, overrides, parsePrefix, formatPrefix)
+}
+
+func (b Builder) AddrPortVar(fs *flag.FlagSet, output *netip.AddrPort, value netip.AddrPort, overrides []Override) {
+	valueVar(b.typed("ip:port"), fs, output, value, overrides, netip.ParseAddrPort, formatAddrPort)
+}
+
+func (b Builder) HostPortVar(fs *flag.FlagSet, output *string, value string, overrides []Override) {
+	valueVar(b.typed("host:port"), fs, output, value, overrides, parseHostPort, formatHostPort)
+}
+
+func (b Builder) IPSliceVar(fs *flag.FlagSet, output *[]netip.Addr, values []netip.Addr, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "ip", netip.ParseAddr, formatAddr, equal[netip.Addr])
+}
+
+func (b Builder) IPPrefixSliceVar(fs *flag.FlagSet, output *[]netip.Prefix, values []netip.Prefix, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "cidr", parsePrefix, formatPrefix, equal[netip.Prefix])
+}
+
+func (b Builder) AddrPortSliceVar(fs *flag.FlagSet, output *[]netip.AddrPort, values []netip.AddrPort, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "ip:port", netip.ParseAddrPort, formatAddrPort, equal[netip.AddrPort])
+}
+
+func (b Builder) HostPortSliceVar(fs *flag.FlagSet, output *[]string, values []string, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "host:port", parseHostPort, formatHostPort, equal[string])
+}
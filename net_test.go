@@ -0,0 +1,132 @@
+package flags_test
+
+import (
+	"flag"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIP(t *testing.T) {
+	t.Setenv("ADDRESS_BIND", "::1")
+
+	fs := flag.NewFlagSet("Address", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	address := flags.New("address", "Listen IP").IP(fs, netip.IPv4Unspecified(), nil)
+	bind := flags.New("bind", "Bind IP").IP(fs, netip.Addr{}, nil)
+	fs.Usage()
+
+	assert.NoError(t, fs.Parse([]string{"--address", "127.0.0.1"}))
+	assert.Equal(t, netip.MustParseAddr("127.0.0.1"), *address)
+	assert.Equal(t, netip.IPv6Loopback(), *bind)
+	assert.Equal(t, "Usage of Address:\n  --address  ip  Listen IP ${ADDRESS_ADDRESS} (default 0.0.0.0)\n  --bind     ip  Bind IP ${ADDRESS_BIND} (default ::1)\n", writer.String())
+
+	assert.EqualError(t, fs.Parse([]string{"--address", "localhost"}), "invalid value \"localhost\" for flag -address: ParseAddr(\"localhost\"): unable to parse IP")
+}
+
+func TestIPPrefix(t *testing.T) {
+	t.Setenv("PREFIX_TRUSTED", "10.1.2.3/8")
+
+	fs := flag.NewFlagSet("Prefix", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	trusted := flags.New("trusted", "Trusted network").IPPrefix(fs, netip.Prefix{}, nil)
+	proxies := flags.New("proxies", "Trusted proxies").IPPrefixSlice(fs, []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")}, nil)
+	fs.Usage()
+
+	assert.NoError(t, fs.Parse([]string{"--proxies", "192.168.1.12/24", "--proxies", "fd00::/8"}))
+	assert.Equal(t, netip.MustParsePrefix("10.0.0.0/8"), *trusted)
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("192.168.1.0/24"), netip.MustParsePrefix("fd00::/8")}, *proxies)
+	assert.Equal(t, "Usage of Prefix:\n  --proxies  cidr slice  Trusted proxies ${PREFIX_PROXIES}, as a cidr slice, environment variable separated by \",\" (default [127.0.0.1/32])\n  --trusted  cidr        Trusted network ${PREFIX_TRUSTED} (default 10.0.0.0/8)\n", writer.String())
+}
+
+func TestAddrPort(t *testing.T) {
+	fs := flag.NewFlagSet("AddrPort", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	listen := flags.New("listen", "Listen address").AddrPort(fs, netip.MustParseAddrPort("0.0.0.0:1080"), nil)
+
+	assert.Equal(t, "0.0.0.0:1080", fs.Lookup("listen").DefValue)
+	assert.NoError(t, fs.Parse([]string{"--listen", "[::1]:8080"}))
+	assert.Equal(t, netip.MustParseAddrPort("[::1]:8080"), *listen)
+	assert.Error(t, fs.Parse([]string{"--listen", "localhost:8080"}))
+}
+
+func TestHostPort(t *testing.T) {
+	cases := map[string]struct {
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		"valid": {
+			[]string{"--upstream", "localhost:8080", "--upstream", "[::1]:80"},
+			[]string{"localhost:8080", "[::1]:80"},
+			"",
+		},
+		"missing port": {
+			[]string{"--upstream", "localhost"},
+			nil,
+			"invalid value \"localhost\" for flag -upstream: address localhost: missing port in address",
+		},
+		"invalid port": {
+			[]string{"--upstream", "localhost:http"},
+			nil,
+			"invalid value \"localhost:http\" for flag -upstream: invalid port `http`",
+		},
+		"empty host": {
+			[]string{"--upstream", ":8080", "--upstream", "my_service.internal.:80"},
+			[]string{":8080", "my_service.internal.:80"},
+			"",
+		},
+		"invalid host": {
+			[]string{"--upstream", "not a host!:80"},
+			nil,
+			"invalid value \"not a host!:80\" for flag -upstream: invalid host `not a host!`",
+		},
+		"invalid label": {
+			[]string{"--upstream", "-api..local:80"},
+			nil,
+			"invalid value \"-api..local:80\" for flag -upstream: invalid host `-api..local`",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("HostPort", flag.ContinueOnError)
+			fs.SetOutput(&strings.Builder{})
+
+			got := flags.New("upstream", "Upstream").HostPortSlice(fs, nil, nil)
+
+			err := fs.Parse(testCase.args)
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, *got)
+		})
+	}
+}
+
+func TestHostPortEnv(t *testing.T) {
+	t.Setenv("PROXY_UPSTREAM", "bad host:80")
+
+	fs := flag.NewFlagSet("Proxy", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	got := flags.New("upstream", "Upstream").HostPort(fs, "localhost:8080", nil)
+
+	assert.Equal(t, "localhost:8080", *got)
+	assert.EqualError(t, flags.Validate(fs), "env `PROXY_UPSTREAM` of flag `upstream`: invalid host `bad host`")
+}
@@ -0,0 +1,135 @@
+package flags
+
+import (
+	"flag"
+	"strconv"
+)
+
+func parseSigned[T ~int8 | ~int16 | ~int32](bitSize int) func(string) (T, error) {
+	return func(input string) (T, error) {
+		intVal, err := strconv.ParseInt(input, 0, bitSize)
+		return T(intVal), err
+	}
+}
+
+func formatSigned[T ~int8 | ~int16 | ~int32](value T) string {
+	return strconv.FormatInt(int64(value), 10)
+}
+
+func parseUnsigned[T ~uint8 | ~uint16 | ~uint32](bitSize int) func(string) (T, error) {
+	return func(input string) (T, error) {
+		intVal, err := strconv.ParseUint(input, 0, bitSize)
+		return T(intVal), err
+	}
+}
+
+func formatUnsigned[T ~uint8 | ~uint16 | ~uint32](value T) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+func parseFloat32(input string) (float32, error) {
+	floatVal, err := strconv.ParseFloat(input, 32)
+	return float32(floatVal), err
+}
+
+func formatFloat32(value float32) string {
+	return strconv.FormatFloat(float64(value), 'g', -1, 32)
+}
+
+// Int8 creates an int8 flag.
+func Int8(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value int8, overrides []Override) *int8 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int8(fs, value, overrides)
+}
+
+// Int8Var binds an int8 flag.
+func Int8Var(fs *flag.FlagSet, output *int8, prefix, docPrefix, name, shorthand, label, env string, value int8, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int8Var(fs, output, value, overrides)
+}
+
+func (b Builder) Int8Var(fs *flag.FlagSet, output *int8, value int8, overrides []Override) {
+	valueVar(b.typed("int8"), fs, output, value, overrides, parseSigned[int8](8), formatSigned[int8])
+}
+
+// Int16 creates an int16 flag.
+func Int16(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value int16, overrides []Override) *int16 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int16(fs, value, overrides)
+}
+
+// Int16Var binds an int16 flag.
+func Int16Var(fs *flag.FlagSet, output *int16, prefix, docPrefix, name, shorthand, label, env string, value int16, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int16Var(fs, output, value, overrides)
+}
+
+func (b Builder) Int16Var(fs *flag.FlagSet, output *int16, value int16, overrides []Override) {
+	valueVar(b.typed("int16"), fs, output, value, overrides, parseSigned[int16](16), formatSigned[int16])
+}
+
+// Int32 creates an int32 flag.
+func Int32(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value int32, overrides []Override) *int32 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int32(fs, value, overrides)
+}
+
+// Int32Var binds an int32 flag.
+func Int32Var(fs *flag.FlagSet, output *int32, prefix, docPrefix, name, shorthand, label, env string, value int32, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Int32Var(fs, output, value, overrides)
+}
+
+func (b Builder) Int32Var(fs *flag.FlagSet, output *int32, value int32, overrides []Override) {
+	valueVar(b.typed("int32"), fs, output, value, overrides, parseSigned[int32](32), formatSigned[int32])
+}
+
+// Uint8 creates an uint8 flag.
+func Uint8(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value uint8, overrides []Override) *uint8 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint8(fs, value, overrides)
+}
+
+// Uint8Var binds an uint8 flag.
+func Uint8Var(fs *flag.FlagSet, output *uint8, prefix, docPrefix, name, shorthand, label, env string, value uint8, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint8Var(fs, output, value, overrides)
+}
+
+func (b Builder) Uint8Var(fs *flag.FlagSet, output *uint8, value uint8, overrides []Override) {
+	valueVar(b.typed("uint8"), fs, output, value, overrides, parseUnsigned[uint8](8), formatUnsigned[uint8])
+}
+
+// Uint16 creates an uint16 flag.
+func Uint16(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value uint16, overrides []Override) *uint16 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint16(fs, value, overrides)
+}
+
+// Uint16Var binds an uint16 flag.
+func Uint16Var(fs *flag.FlagSet, output *uint16, prefix, docPrefix, name, shorthand, label, env string, value uint16, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint16Var(fs, output, value, overrides)
+}
+
+func (b Builder) Uint16Var(fs *flag.FlagSet, output *uint16, value uint16, overrides []Override) {
+	valueVar(b.typed("uint16"), fs, output, value, overrides, parseUnsigned[uint16](16), formatUnsigned[uint16])
+}
+
+// Uint32 creates an uint32 flag.
+func Uint32(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value uint32, overrides []Override) *uint32 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint32(fs, value, overrides)
+}
+
+// Uint32Var binds an uint32 flag.
+func Uint32Var(fs *flag.FlagSet, output *uint32, prefix, docPrefix, name, shorthand, label, env string, value uint32, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Uint32Var(fs, output, value, overrides)
+}
+
+func (b Builder) Uint32Var(fs *flag.FlagSet, output *uint32, value uint32, overrides []Override) {
+	valueVar(b.typed("uint32"), fs, output, value, overrides, parseUnsigned[uint32](32), formatUnsigned[uint32])
+}
+
+// Float32 creates a float32 flag.
+func Float32(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env string, value float32, overrides []Override) *float32 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Float32(fs, value, overrides)
+}
+
+// Float32Var binds a float32 flag.
+func Float32Var(fs *flag.FlagSet, output *float32, prefix, docPrefix, name, shorthand, label, env string, value float32, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, "").Float32Var(fs, output, value, overrides)
+}
+
+func (b Builder) Float32Var(fs *flag.FlagSet, output *float32, value float32, overrides []Override) {
+	valueVar(b.typed("float32"), fs, output, value, overrides, parseFloat32, formatFloat32)
+}
@@ -0,0 +1,46 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizedNumbers(t *testing.T) {
+	t.Setenv("SIZED_RETRIES", "0x7f")
+	t.Setenv("SIZED_PORT", "70000")
+
+	fs := flag.NewFlagSet("Sized", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	retries := flags.New("retries", "Retries").Int8(fs, 3, nil)
+	offset := flags.New("offset", "Offset").Int16(fs, -1, nil)
+	delta := flags.New("delta", "Delta").Int32(fs, 0, nil)
+	level := flags.New("level", "Level").Uint8(fs, 1, nil)
+	port := flags.New("port", "Port").Uint16(fs, 8080, nil)
+	count := flags.New("count", "Count").Uint32(fs, 0, nil)
+	ratio := flags.New("ratio", "Ratio").Float32(fs, 0.5, nil)
+	fs.Usage()
+
+	assert.Equal(t, int8(127), *retries)
+	assert.Equal(t, uint16(8080), *port)
+	assert.EqualError(t, flags.Validate(fs), "env `SIZED_PORT` of flag `port`: strconv.ParseUint: parsing \"70000\": value out of range")
+	assert.Equal(t, "Usage of Sized:\n  --count    uint32   Count ${SIZED_COUNT} (default 0)\n  --delta    int32    Delta ${SIZED_DELTA} (default 0)\n  --level    uint8    Level ${SIZED_LEVEL} (default 1)\n  --offset   int16    Offset ${SIZED_OFFSET} (default -1)\n  --port     uint16   Port ${SIZED_PORT} (default 8080)\n  --ratio    float32  Ratio ${SIZED_RATIO} (default 0.5)\n  --retries  int8     Retries ${SIZED_RETRIES} (default 127)\n", writer.String())
+
+	assert.NoError(t, fs.Parse([]string{"--offset", "-0b101", "--delta", "1_000_000", "--level", "0o17", "--count", "4294967295", "--ratio", "0.25"}))
+	assert.Equal(t, int16(-5), *offset)
+	assert.Equal(t, int32(1000000), *delta)
+	assert.Equal(t, uint8(15), *level)
+	assert.Equal(t, uint32(4294967295), *count)
+	assert.Equal(t, float32(0.25), *ratio)
+
+	assert.EqualError(t, fs.Parse([]string{"--retries", "128"}), "invalid value \"128\" for flag -retries: strconv.ParseInt: parsing \"128\": value out of range")
+	assert.EqualError(t, fs.Parse([]string{"--level", "-1"}), "invalid value \"-1\" for flag -level: strconv.ParseUint: parsing \"-1\": invalid syntax")
+	assert.EqualError(t, fs.Parse([]string{"--ratio", "1e39"}), "invalid value \"1e39\" for flag -ratio: strconv.ParseFloat: parsing \"1e39\": value out of range")
+}
@@ -0,0 +1,127 @@
+package flags
+
+import (
+	"flag"
+	"strconv"
+	"time"
+)
+
+// Optional is a flag's value that distinguishes unset from zero value.
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// IsSet reports whether the value has been provided by argument, environment variable or override.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// Get returns the value, the zero value if unset.
+func (o Optional[T]) Get() T {
+	return o.value
+}
+
+// Or returns the value if set, the fallback otherwise.
+func (o Optional[T]) Or(fallback T) T {
+	if o.set {
+		return o.value
+	}
+
+	return fallback
+}
+
+type optionalValue[T any] struct {
+	output *Optional[T]
+	parse  func(string) (T, error)
+	format func(T) string
+	isBool bool
+}
+
+func (o *optionalValue[T]) String() string {
+	if o == nil || o.output == nil || !o.output.set {
+		return ""
+	}
+
+	return o.format(o.output.value)
+}
+
+func (o *optionalValue[T]) Get() any {
+	if !o.output.set {
+		return nil
+	}
+
+	return o.output.value
+}
+
+func (o *optionalValue[T]) Set(value string) error {
+	parsed, err := o.parse(value)
+	if err != nil {
+		return err
+	}
+
+	*o.output = Optional[T]{value: parsed, set: true}
+
+	return nil
+}
+
+func (o *optionalValue[T]) IsBoolFlag() bool {
+	return o.isBool
+}
+
+// OptionalFlag creates an optional flag, unset unless provided by argument, environment variable or override.
+func OptionalFlag[T any](b Builder, fs *flag.FlagSet, overrides []Override, parse func(string) (T, error), format func(T) string) *Optional[T] {
+	output := new(Optional[T])
+
+	OptionalVar(b, fs, output, overrides, parse, format)
+
+	return output
+}
+
+// OptionalVar binds an optional flag, unset unless provided by argument, environment variable or override.
+func OptionalVar[T any](b Builder, fs *flag.FlagSet, output *Optional[T], overrides []Override, parse func(string) (T, error), format func(T) string) {
+	optionalVar(b, fs, output, overrides, parse, format, false)
+}
+
+func (b Builder) OptionalStringVar(fs *flag.FlagSet, output *Optional[string], overrides []Override) {
+	optionalVar(b.typed("string"), fs, output, overrides, func(input string) (string, error) {
+		return input, nil
+	}, func(value string) string {
+		return value
+	}, false)
+}
+
+func (b Builder) OptionalIntVar(fs *flag.FlagSet, output *Optional[int], overrides []Override) {
+	optionalVar(b.typed("int"), fs, output, overrides, func(input string) (int, error) {
+		intVal, err := strconv.ParseInt(input, 0, strconv.IntSize)
+		return int(intVal), err
+	}, strconv.Itoa, false)
+}
+
+func (b Builder) OptionalBoolVar(fs *flag.FlagSet, output *Optional[bool], overrides []Override) {
+	optionalVar(b, fs, output, overrides, strconv.ParseBool, strconv.FormatBool, true)
+}
+
+func (b Builder) OptionalDurationVar(fs *flag.FlagSet, output *Optional[time.Duration], overrides []Override) {
+	optionalVar(b.typed("duration"), fs, output, overrides, time.ParseDuration, time.Duration.String, false)
+}
+
+func optionalVar[T any](b Builder, fs *flag.FlagSet, output *Optional[T], overrides []Override, parse func(string) (T, error), format func(T) string, isBool bool) {
+	flagName, envName, usage := b.computeDescription(fs)
+
+	*output = Optional[T]{}
+	if hasOverride(b.name, overrides) {
+		var zero T
+		*output = Optional[T]{value: defaultStaticValue(b.name, zero, overrides), set: true}
+	}
+
+	fs.Var(&optionalValue[T]{output: output, parse: parse, format: format, isBool: isBool}, flagName, usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, func(input string) (Optional[T], error) {
+		parsed, err := parse(input)
+		if err != nil {
+			return Optional[T]{}, err
+		}
+
+		return Optional[T]{value: parsed, set: true}, nil
+	})
+}
@@ -0,0 +1,138 @@
+package flags_test
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionalBool(t *testing.T) {
+	type args struct {
+		overrides []flags.Override
+		args      []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		wantSet   bool
+		want      bool
+		wantUsage string
+	}{
+		"unset": {
+			flags.New("feature", "Enable feature"),
+			nil,
+			args{},
+			false,
+			false,
+			"Usage of OptionalBool:\n  --feature    Enable feature ${OPTIONAL_BOOL_FEATURE}\n",
+		},
+		"explicit false": {
+			flags.New("feature", "Enable feature"),
+			nil,
+			args{
+				args: []string{"--feature=false"},
+			},
+			true,
+			false,
+			"Usage of OptionalBool:\n  --feature    Enable feature ${OPTIONAL_BOOL_FEATURE}\n",
+		},
+		"without value": {
+			flags.New("feature", "Enable feature").Shorthand("f"),
+			nil,
+			args{
+				args: []string{"-f"},
+			},
+			true,
+			true,
+			"Usage of OptionalBool:\n  -f, --feature    Enable feature ${OPTIONAL_BOOL_FEATURE}\n",
+		},
+		"with env": {
+			flags.New("cache", "Enable cache"),
+			func() {
+				t.Setenv("OPTIONAL_BOOL_CACHE", "false")
+			},
+			args{},
+			true,
+			false,
+			"Usage of OptionalBool:\n  --cache    Enable cache ${OPTIONAL_BOOL_CACHE} (default false)\n",
+		},
+		"with override": {
+			flags.New("beta", "Enable beta"),
+			nil,
+			args{
+				overrides: []flags.Override{flags.NewOverride("beta", true)},
+			},
+			true,
+			true,
+			"Usage of OptionalBool:\n  --beta    Enable beta ${OPTIONAL_BOOL_BETA} (default true)\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("OptionalBool", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.OptionalBool(fs, testCase.args.overrides)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.wantSet, got.IsSet())
+			assert.Equal(t, testCase.want, got.Get())
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestOptionalInt(t *testing.T) {
+	fs := flag.NewFlagSet("OptionalInt", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	workers := flags.New("workers", "Number of workers").OptionalInt(fs, nil)
+	retries := flags.New("retries", "Number of retries").OptionalInt(fs, nil)
+	fs.Usage()
+
+	assert.NoError(t, fs.Parse([]string{"--workers", "0"}))
+
+	assert.True(t, workers.IsSet())
+	assert.Equal(t, 0, workers.Or(4))
+	assert.False(t, retries.IsSet())
+	assert.Equal(t, 3, retries.Or(3))
+	assert.Equal(t, "Usage of OptionalInt:\n  --retries  int  Number of retries ${OPTIONAL_INT_RETRIES}\n  --workers  int  Number of workers ${OPTIONAL_INT_WORKERS}\n", writer.String())
+}
+
+func TestOptionalFlag(t *testing.T) {
+	t.Setenv("OPTIONAL_FLAG_RATE", "0.5")
+
+	fs := flag.NewFlagSet("OptionalFlag", flag.ContinueOnError)
+
+	parse := func(input string) (float64, error) {
+		return strconv.ParseFloat(input, 64)
+	}
+
+	format := func(value float64) string {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+
+	got := flags.OptionalFlag(flags.New("rate", "Sampling rate"), fs, nil, parse, format)
+
+	assert.NoError(t, fs.Parse(nil))
+	assert.True(t, got.IsSet())
+	assert.Equal(t, 0.5, got.Get())
+}
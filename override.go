@@ -16,6 +16,16 @@ func NewOverride(name string, value any) Override {
 	}
 }
 
+func hasOverride(name string, overrides []Override) bool {
+	for _, override := range overrides {
+		if strings.EqualFold(name, override.name) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func defaultStaticValue[T any](name string, value T, overrides []Override) T {
 	for _, override := range overrides {
 		if strings.EqualFold(name, override.name) {
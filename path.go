@@ -0,0 +1,242 @@
+package flags
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathConstraint validates an expanded path.
+type PathConstraint func(string) error
+
+// MustExist rejects path that doesn't exist.
+func MustExist() PathConstraint {
+	return func(path string) error {
+		_, err := os.Stat(path)
+		return err
+	}
+}
+
+// Readable rejects path that can't be opened for reading.
+func Readable() PathConstraint {
+	return func(path string) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		return file.Close()
+	}
+}
+
+// Writable rejects path that is not writable, or whose parent directory isn't when it doesn't exist. It checks permissions without writing anything.
+func Writable() PathConstraint {
+	return func(path string) error {
+		_, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			path = filepath.Dir(path)
+		} else if err != nil {
+			return err
+		}
+
+		if err := checkWritable(path); err != nil {
+			return fmt.Errorf("`%s` is not writable: %w", path, err)
+		}
+
+		return nil
+	}
+}
+
+// Mode rejects path whose permissions grant more than perm, e.g. Mode(0o600) for a private key.
+func Mode(perm os.FileMode) PathConstraint {
+	return func(path string) error {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if extra := info.Mode().Perm() &^ perm; extra != 0 {
+			return fmt.Errorf("mode %s of `%s` is more permissive than %s", info.Mode().Perm(), path, perm)
+		}
+
+		return nil
+	}
+}
+
+func isFile(path string) error {
+	info, err := os.Stat(path)
+	if err == nil && info.IsDir() {
+		return fmt.Errorf("`%s` is a directory", path)
+	}
+
+	return nil
+}
+
+func isDir(path string) error {
+	info, err := os.Stat(path)
+	if err == nil && !info.IsDir() {
+		return fmt.Errorf("`%s` is not a directory", path)
+	}
+
+	return nil
+}
+
+// ExpandPath replaces a leading `~` by the user's home directory and environment variable references by their value.
+func ExpandPath(input string) (string, error) {
+	output := os.ExpandEnv(input)
+
+	if output == "~" || strings.HasPrefix(output, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+
+		output = home + output[1:]
+	}
+
+	return output, nil
+}
+
+func parsePath(constraints []PathConstraint) func(string) (string, error) {
+	return func(input string) (string, error) {
+		if len(input) == 0 {
+			return "", nil
+		}
+
+		path, err := ExpandPath(input)
+		if err != nil {
+			return "", err
+		}
+
+		for _, constraint := range constraints {
+			if err := constraint(path); err != nil {
+				return "", err
+			}
+		}
+
+		return path, nil
+	}
+}
+
+// resolveDefaultPath expands and checks the default path against the constraints. The expanded path is returned even if it doesn't satisfy them.
+func resolveDefaultPath(name, value string, overrides []Override, parse func(string) (string, error)) (string, error) {
+	value = defaultStaticValue(name, value, overrides)
+
+	path, err := parse(value)
+	if err == nil {
+		return path, nil
+	}
+
+	if expanded, expandErr := ExpandPath(value); expandErr == nil {
+		return expanded, err
+	}
+
+	return value, err
+}
+
+func formatPath(value string) string {
+	return value
+}
+
+// FileVar binds a file path flag. The path is expanded, rejected when it's a directory and checked against the constraints. Validate reports a default value that doesn't satisfy them.
+func (b Builder) FileVar(fs *flag.FlagSet, output *string, value string, overrides []Override, constraints ...PathConstraint) {
+	b.pathVar(fs, output, value, overrides, "file", append([]PathConstraint{isFile}, constraints...))
+}
+
+// DirVar binds a directory path flag. The path is expanded, rejected when it's not a directory and checked against the constraints. Validate reports a default value that doesn't satisfy them.
+func (b Builder) DirVar(fs *flag.FlagSet, output *string, value string, overrides []Override, constraints ...PathConstraint) {
+	b.pathVar(fs, output, value, overrides, "dir", append([]PathConstraint{isDir}, constraints...))
+}
+
+func (b Builder) pathVar(fs *flag.FlagSet, output *string, value string, overrides []Override, typeName string, constraints []PathConstraint) {
+	b = b.typed(typeName)
+	parse := parsePath(constraints)
+
+	defaultPath, err := resolveDefaultPath(b.name, value, overrides, parse)
+	b.defaultErr = err
+
+	flagName, envName, usage := b.computeDescription(fs)
+
+	fs.Var(newValue(defaultPath, output, parse, formatPath), flagName, usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, parse)
+}
+
+type fileContentValue struct {
+	output *[]byte
+	parse  func(string) (string, error)
+	path   string
+}
+
+func (f *fileContentValue) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return f.path
+}
+
+func (f *fileContentValue) Get() any {
+	return *f.output
+}
+
+func (f *fileContentValue) Set(input string) error {
+	path, err := f.parse(input)
+	if err != nil {
+		return err
+	}
+
+	var content []byte
+
+	if len(path) > 0 {
+		content, err = os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+	}
+
+	f.path = path
+	*f.output = content
+
+	return nil
+}
+
+// FileContentVar binds a flag given a file path and reads its content, e.g. a TLS certificate. Usage and dumps show the path.
+// The default file is checked and read at registration: its content is left empty when it can't be, and Validate reports why.
+func (b Builder) FileContentVar(fs *flag.FlagSet, output *[]byte, value string, overrides []Override, constraints ...PathConstraint) {
+	b = b.typed("file")
+
+	parse := parsePath(append([]PathConstraint{isFile}, constraints...))
+	defaultPath, err := resolveDefaultPath(b.name, value, overrides, parse)
+
+	target := &fileContentValue{
+		output: output,
+		parse:  parse,
+		path:   defaultPath,
+	}
+
+	*output = nil
+	if err == nil && len(defaultPath) > 0 {
+		*output, err = os.ReadFile(defaultPath)
+	}
+
+	b.defaultErr = err
+
+	flagName, envName, usage := b.computeDescription(fs)
+
+	fs.Var(target, flagName, usage)
+	bindEnv(b, fs, flagName, envName, usage, output, overrides, func() (string, []byte, bool, error) {
+		val, ok := lookupEnv(fs, envName, b.deprecatedEnvs)
+		if !ok {
+			return "", nil, false, nil
+		}
+
+		err := target.Set(val)
+
+		return val, *output, true, err
+	})
+}
@@ -0,0 +1,277 @@
+package flags_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/bob")
+	t.Setenv("APP_DIR", "/srv/app")
+
+	cases := map[string]struct {
+		input string
+		want  string
+	}{
+		"plain": {
+			"/etc/hosts",
+			"/etc/hosts",
+		},
+		"home": {
+			"~/.config/app",
+			"/home/bob/.config/app",
+		},
+		"not home": {
+			"~bob/app",
+			"~bob/app",
+		},
+		"env": {
+			"${APP_DIR}/templates",
+			"/srv/app/templates",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			got, err := flags.ExpandPath(testCase.input)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+
+	key := filepath.Join(dir, "key.pem")
+	assert.NoError(t, os.WriteFile(key, []byte("private"), 0o644))
+
+	t.Setenv("DATA_DIR", dir)
+
+	cases := map[string]struct {
+		constraints []flags.PathConstraint
+		args        []string
+		want        string
+		wantErr     string
+	}{
+		"expanded": {
+			nil,
+			[]string{"--key", "${DATA_DIR}/key.pem"},
+			key,
+			"",
+		},
+		"not existing": {
+			nil,
+			[]string{"--key", "${DATA_DIR}/missing.pem"},
+			filepath.Join(dir, "missing.pem"),
+			"",
+		},
+		"must exist": {
+			[]flags.PathConstraint{flags.MustExist()},
+			[]string{"--key", "${DATA_DIR}/missing.pem"},
+			"",
+			"invalid value \"${DATA_DIR}/missing.pem\" for flag -key: stat " + filepath.Join(dir, "missing.pem") + ": no such file or directory",
+		},
+		"directory": {
+			nil,
+			[]string{"--key", "${DATA_DIR}"},
+			"",
+			"invalid value \"${DATA_DIR}\" for flag -key: `" + dir + "` is a directory",
+		},
+		"mode": {
+			[]flags.PathConstraint{flags.Mode(0o600)},
+			[]string{"--key", "${DATA_DIR}/key.pem"},
+			"",
+			"invalid value \"${DATA_DIR}/key.pem\" for flag -key: mode -rw-r--r-- of `" + key + "` is more permissive than -rw-------",
+		},
+		"readable and writable": {
+			[]flags.PathConstraint{flags.Readable(), flags.Writable()},
+			[]string{"--key", "${DATA_DIR}/key.pem"},
+			key,
+			"",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("File", flag.ContinueOnError)
+			fs.SetOutput(&strings.Builder{})
+
+			got := flags.New("key", "Private key").File(fs, "", nil, testCase.constraints...)
+
+			err := fs.Parse(testCase.args)
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, *got)
+		})
+	}
+}
+
+func TestDir(t *testing.T) {
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	fs := flag.NewFlagSet("Dir", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	output := flags.New("output", "Output directory").Dir(fs, "/tmp", nil, flags.MustExist(), flags.Writable())
+	fs.Usage()
+
+	assert.Equal(t, "Usage of Dir:\n  --output  dir  Output directory ${DIR_OUTPUT} (default /tmp)\n", writer.String())
+	assert.NoError(t, fs.Parse([]string{"--output", dir}))
+	assert.Equal(t, dir, *output)
+	assert.EqualError(t, fs.Parse([]string{"--output", file}), "invalid value \""+file+"\" for flag -output: `"+file+"` is not a directory")
+}
+
+func TestFileContent(t *testing.T) {
+	dir := t.TempDir()
+
+	cert := filepath.Join(dir, "cert.pem")
+	assert.NoError(t, os.WriteFile(cert, []byte("certificate"), 0o600))
+
+	template := filepath.Join(dir, "index.html")
+	assert.NoError(t, os.WriteFile(template, []byte("<html>"), 0o600))
+
+	t.Setenv("CONTENT_CERT", cert)
+
+	fs := flag.NewFlagSet("Content", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	certContent := flags.New("cert", "Certificate").FileContent(fs, "", nil)
+	templateContent := flags.New("template", "Template").FileContent(fs, "", nil, flags.Readable())
+	fs.Usage()
+
+	assert.Equal(t, []byte("certificate"), *certContent)
+	assert.Nil(t, *templateContent)
+	assert.Equal(t, "Usage of Content:\n  --cert      file  Certificate ${CONTENT_CERT} (default "+cert+")\n  --template  file  Template ${CONTENT_TEMPLATE}\n", writer.String())
+
+	assert.NoError(t, fs.Parse([]string{"--template", template}))
+	assert.Equal(t, []byte("<html>"), *templateContent)
+	assert.Equal(t, template, fs.Lookup("template").Value.String())
+
+	assert.Error(t, fs.Parse([]string{"--template", filepath.Join(dir, "missing.html")}))
+	assert.Equal(t, []byte("<html>"), *templateContent)
+}
+
+func TestFileOverride(t *testing.T) {
+	t.Setenv("HOME", "")
+	t.Setenv("CONFIG_DIR", "/etc/app")
+
+	fs := flag.NewFlagSet("Override", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	home := flags.New("home", "Home file").File(fs, "~/default", []flags.Override{flags.NewOverride("home", "~/override")})
+	config := flags.New("config", "Config file").File(fs, "default.yaml", []flags.Override{flags.NewOverride("config", "${CONFIG_DIR}/app.yaml")})
+
+	assert.Equal(t, "~/override", *home)
+	assert.Equal(t, "/etc/app/app.yaml", *config)
+}
+
+func TestFileContentDefault(t *testing.T) {
+	dir := t.TempDir()
+
+	cert := filepath.Join(dir, "cert.pem")
+	assert.NoError(t, os.WriteFile(cert, []byte("certificate"), 0o600))
+
+	missing := filepath.Join(dir, "missing.pem")
+
+	cases := map[string]struct {
+		preTest func(*testing.T)
+		args    []string
+		want    []byte
+		wantErr string
+	}{
+		"missing default": {
+			nil,
+			nil,
+			nil,
+			"default of flag `cert`: stat " + missing + ": no such file or directory",
+		},
+		"replaced by env": {
+			func(t *testing.T) {
+				t.Setenv("DEFAULT_CERT", cert)
+			},
+			nil,
+			[]byte("certificate"),
+			"",
+		},
+		"replaced by argument": {
+			nil,
+			[]string{"--cert", cert},
+			[]byte("certificate"),
+			"",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			if testCase.preTest != nil {
+				testCase.preTest(t)
+			}
+
+			fs := flag.NewFlagSet("Default", flag.ContinueOnError)
+			fs.SetOutput(&strings.Builder{})
+
+			got := flags.New("cert", "Certificate").FileContent(fs, missing, nil, flags.MustExist())
+
+			assert.NoError(t, fs.Parse(testCase.args))
+			assert.Equal(t, testCase.want, *got)
+
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, flags.Validate(fs), testCase.wantErr)
+			} else {
+				assert.NoError(t, flags.Validate(fs))
+			}
+		})
+	}
+}
+
+func TestFileDefault(t *testing.T) {
+	dir := t.TempDir()
+
+	fs := flag.NewFlagSet("Default", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	got := flags.New("template", "Template").File(fs, dir, nil)
+
+	assert.Equal(t, dir, *got)
+	assert.EqualError(t, flags.Validate(fs), "default of flag `template`: `"+dir+"` is a directory")
+}
+
+func TestWritable(t *testing.T) {
+	dir := t.TempDir()
+	missingDir := filepath.Join(dir, "missing")
+
+	fs := flag.NewFlagSet("Writable", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	output := flags.New("output", "Output").File(fs, "", nil, flags.Writable())
+
+	assert.NoError(t, fs.Parse([]string{"--output", filepath.Join(dir, "report.csv")}))
+	assert.Equal(t, filepath.Join(dir, "report.csv"), *output)
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.EqualError(t, fs.Parse([]string{"--output", filepath.Join(missingDir, "report.csv")}), "invalid value \""+filepath.Join(missingDir, "report.csv")+"\" for flag -output: `"+missingDir+"` is not writable: no such file or directory")
+}
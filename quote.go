@@ -0,0 +1,79 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// splitQuoted splits input by separator, with RFC 4180 double quotes and backslash escaping.
+func splitQuoted(input, separator string) ([]string, error) {
+	var (
+		output  []string
+		current strings.Builder
+		quoted  bool
+		closed  bool
+	)
+
+	for index := 0; index < len(input); index++ {
+		char := input[index]
+
+		switch {
+		case char == '\\' && index+1 < len(input):
+			index++
+			current.WriteByte(input[index])
+
+		case quoted && char == '"':
+			if index+1 < len(input) && input[index+1] == '"' {
+				index++
+				current.WriteByte('"')
+			} else {
+				quoted = false
+				closed = true
+			}
+
+		case !quoted && strings.HasPrefix(input[index:], separator):
+			output = append(output, current.String())
+			current.Reset()
+			closed = false
+			index += len(separator) - 1
+
+		case closed:
+			return nil, fmt.Errorf("unexpected `%c` after closing quote at position %d", char, index)
+
+		case !quoted && char == '"' && current.Len() == 0:
+			quoted = true
+
+		default:
+			current.WriteByte(char)
+		}
+	}
+
+	if quoted {
+		return nil, errUnterminatedQuote
+	}
+
+	return append(output, current.String()), nil
+}
+
+func (b Builder) splitEnv(input string) ([]string, error) {
+	if b.quoted {
+		return splitQuoted(input, b.envSeparator)
+	}
+
+	return strings.Split(input, b.envSeparator), nil
+}
+
+// quote surrounds value with double quotes if it contains one of the separators, quote or backslash.
+func quote(value string, separators ...string) string {
+	if !slices.ContainsFunc(separators, func(separator string) bool {
+		return strings.Contains(value, separator)
+	}) && !strings.ContainsAny(value, `"\`) {
+		return value
+	}
+
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), `"`, `""`) + `"`
+}
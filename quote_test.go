@@ -0,0 +1,103 @@
+package flags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitQuoted(t *testing.T) {
+	cases := map[string]struct {
+		input     string
+		separator string
+		want      []string
+		wantErr   bool
+	}{
+		"should work with simple values": {
+			"a,b,c",
+			",",
+			[]string{"a", "b", "c"},
+			false,
+		},
+		"should work with quoted separator": {
+			`"a,b",c`,
+			",",
+			[]string{"a,b", "c"},
+			false,
+		},
+		"should work with escaped quote": {
+			`"say ""hello""",c`,
+			",",
+			[]string{`say "hello"`, "c"},
+			false,
+		},
+		"should work with backslash escaping": {
+			`a\,b,c\\d`,
+			",",
+			[]string{"a,b", `c\d`},
+			false,
+		},
+		"should work with multi-char separator": {
+			`a||"b||c"`,
+			"||",
+			[]string{"a", "b||c"},
+			false,
+		},
+		"should fail with unterminated quote": {
+			`"a,b`,
+			",",
+			nil,
+			true,
+		},
+		"should fail with content after closing quote": {
+			`"a"b,c`,
+			",",
+			nil,
+			true,
+		},
+	}
+
+	for intention, tc := range cases {
+		t.Run(intention, func(t *testing.T) {
+			result, err := splitQuoted(tc.input, tc.separator)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("splitQuoted() error = %v, wantErr %t", err, tc.wantErr)
+			}
+
+			if !reflect.DeepEqual(result, tc.want) {
+				t.Errorf("splitQuoted() = %q, want %q", result, tc.want)
+			}
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	cases := map[string]struct {
+		input string
+		want  string
+	}{
+		"should not quote simple value": {
+			"abc",
+			"abc",
+		},
+		"should quote separator": {
+			"a,b",
+			`"a,b"`,
+		},
+		"should escape quote and backslash": {
+			`say "hi" \o/`,
+			`"say ""hi"" \\o/"`,
+		},
+	}
+
+	for intention, tc := range cases {
+		t.Run(intention, func(t *testing.T) {
+			if result := quote(tc.input, ","); result != tc.want {
+				t.Errorf("quote() = `%s`, want `%s`", result, tc.want)
+			}
+
+			if result, err := splitQuoted(quote(tc.input, ","), ","); err != nil || len(result) != 1 || result[0] != tc.input {
+				t.Errorf("splitQuoted(quote()) = %q, want `%s`", result, tc.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseRatio parses a ratio between 0 and 1, given as a percentage, e.g. `10%`, or as a fraction, e.g. `0.1`.
+func ParseRatio(input string) (float64, error) {
+	trimmed := strings.TrimSpace(input)
+
+	number, percentage := strings.CutSuffix(trimmed, "%")
+
+	output, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ratio `%s`", input)
+	}
+
+	if percentage {
+		output /= 100
+	}
+
+	if !(output >= 0 && output <= 1) {
+		return 0, fmt.Errorf("ratio `%s` is not between 0 and 1, or 0%% and 100%%", input)
+	}
+
+	return output, nil
+}
+
+// FormatRatio renders a ratio as a percentage, e.g. `12.5%`.
+func FormatRatio(value float64) string {
+	return strconv.FormatFloat(value*100, 'g', 10, 64) + "%"
+}
+
+// RatioVar binds a ratio flag, accepting `10%` or `0.1` and rendered as a percentage in Usage.
+func (b Builder) RatioVar(fs *flag.FlagSet, output *float64, value float64, overrides []Override) {
+	valueVar(b.typed("ratio"), fs, output, value, overrides, ParseRatio, FormatRatio)
+}
@@ -0,0 +1,94 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRatio(t *testing.T) {
+	cases := map[string]struct {
+		input   string
+		want    float64
+		wantErr string
+	}{
+		"fraction": {
+			"0.1",
+			0.1,
+			"",
+		},
+		"percentage": {
+			"12.5%",
+			0.125,
+			"",
+		},
+		"bounds": {
+			"100%",
+			1,
+			"",
+		},
+		"spaces": {
+			" 5 % ",
+			0.05,
+			"",
+		},
+		"out of range": {
+			"10",
+			0,
+			"ratio `10` is not between 0 and 1, or 0% and 100%",
+		},
+		"negative percentage": {
+			"-1%",
+			0,
+			"ratio `-1%` is not between 0 and 1, or 0% and 100%",
+		},
+		"not a number": {
+			"NaN",
+			0,
+			"ratio `NaN` is not between 0 and 1, or 0% and 100%",
+		},
+		"invalid": {
+			"half",
+			0,
+			"invalid ratio `half`",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			got, err := flags.ParseRatio(testCase.input)
+
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.InDelta(t, testCase.want, got, 1e-12)
+		})
+	}
+}
+
+func TestRatio(t *testing.T) {
+	t.Setenv("TRACING_SAMPLING", "7%")
+
+	fs := flag.NewFlagSet("Tracing", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	sampling := flags.New("sampling", "Sampling rate").Ratio(fs, 0.1, nil)
+	threshold := flags.New("threshold", "Error threshold").Ratio(fs, 0.125, nil)
+	fs.Usage()
+
+	assert.InDelta(t, 0.07, *sampling, 1e-12)
+	assert.Equal(t, "Usage of Tracing:\n  --sampling   ratio  Sampling rate ${TRACING_SAMPLING} (default 7%)\n  --threshold  ratio  Error threshold ${TRACING_THRESHOLD} (default 12.5%)\n", writer.String())
+
+	assert.NoError(t, fs.Parse([]string{"--threshold", "0.5"}))
+	assert.Equal(t, 0.5, *threshold)
+	assert.EqualError(t, fs.Parse([]string{"--threshold", "50"}), "invalid value \"50\" for flag -threshold: ratio `50` is not between 0 and 1, or 0% and 100%")
+}
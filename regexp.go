@@ -0,0 +1,32 @@
+package flags
+
+import (
+	"flag"
+	"regexp"
+)
+
+// parseRegexp compiles the pattern, an empty one leaving the flag unset instead of matching everything.
+func parseRegexp(input string) (*regexp.Regexp, error) {
+	if len(input) == 0 {
+		return nil, nil
+	}
+
+	return regexp.Compile(input)
+}
+
+func formatRegexp(value *regexp.Regexp) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
+
+// RegexpVar binds a regular expression flag, compiled from the argument or the environment variable. Usage shows the source pattern. The value is nil when unset.
+func (b Builder) RegexpVar(fs *flag.FlagSet, output **regexp.Regexp, value *regexp.Regexp, overrides []Override) {
+	valueVar(b.typed("regexp"), fs, output, value, overrides, parseRegexp, formatRegexp)
+}
+
+func (b Builder) RegexpSliceVar(fs *flag.FlagSet, output *[]*regexp.Regexp, values []*regexp.Regexp, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "regexp", regexp.Compile, formatRegexp, nil)
+}
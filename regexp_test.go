@@ -0,0 +1,50 @@
+package flags_test
+
+import (
+	"flag"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegexp(t *testing.T) {
+	t.Setenv("ROUTER_ALLOW", "^/api/.*$,^/health$")
+
+	fs := flag.NewFlagSet("Router", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	path := flags.New("path", "Path pattern").Regexp(fs, regexp.MustCompile("^/static/"), nil)
+	deny := flags.New("deny", "Denied paths").Regexp(fs, nil, nil)
+	allow := flags.New("allow", "Allowed paths").RegexpSlice(fs, nil, nil)
+	fs.Usage()
+
+	assert.NoError(t, fs.Parse([]string{"--path", `^/assets/.*\.js$`}))
+	assert.True(t, (*path).MatchString("/assets/app.js"))
+	assert.False(t, (*path).MatchString("/static/app.js"))
+	assert.Nil(t, *deny)
+	assert.Equal(t, []*regexp.Regexp{regexp.MustCompile("^/api/.*$"), regexp.MustCompile("^/health$")}, *allow)
+	assert.Equal(t, "Usage of Router:\n  --allow  regexp slice  Allowed paths ${ROUTER_ALLOW}, as a regexp slice, environment variable separated by \",\" (default [^/api/.*$, ^/health$])\n  --deny   regexp        Denied paths ${ROUTER_DENY}\n  --path   regexp        Path pattern ${ROUTER_PATH} (default ^/static/)\n", writer.String())
+
+	assert.NoError(t, fs.Parse([]string{"--path", ""}))
+	assert.Nil(t, *path)
+
+	assert.EqualError(t, fs.Parse([]string{"--path", "[a-"}), "invalid value \"[a-\" for flag -path: error parsing regexp: missing closing ]: `[a-`")
+}
+
+func TestRegexpEnvError(t *testing.T) {
+	t.Setenv("FILTER_EXCLUDE", "(unclosed")
+
+	fs := flag.NewFlagSet("Filter", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	exclude := flags.New("exclude", "Excluded pattern").Regexp(fs, regexp.MustCompile("^$"), nil)
+
+	assert.Equal(t, "^$", (*exclude).String())
+	assert.EqualError(t, flags.Validate(fs), "env `FILTER_EXCLUDE` of flag `exclude`: error parsing regexp: missing closing ): `(unclosed`")
+}
@@ -0,0 +1,156 @@
+package flags
+
+import (
+	"errors"
+	"flag"
+	"runtime"
+	"slices"
+	"sync"
+	"weak"
+)
+
+// Source is the origin of a flag's value.
+type Source string
+
+const (
+	SourceDefault  Source = "default"
+	SourceOverride Source = "override"
+	SourceEnv      Source = "env"
+	SourceArgument Source = "argument"
+)
+
+const redacted = "*****"
+
+type entry struct {
+	err            error
+	defaultErr     error
+	name           string
+	shorthand      string
+	env            string
+	group          string
+	typeName       string
+	defaultValue   string
+	envValue       string
+	source         Source
+	aliases        []string
+	deprecatedEnvs []string
+	envSet         bool
+	hidden         bool
+	sensitive      bool
+}
+
+func (e *entry) names() []string {
+	output := []string{e.name}
+	if len(e.shorthand) > 0 {
+		output = append(output, e.shorthand)
+	}
+
+	return append(output, e.aliases...)
+}
+
+// currentSource returns the source of the value, taking argument into account once parsed.
+func (e *entry) currentSource(fs *flag.FlagSet) Source {
+	source := e.source
+
+	names := e.names()
+	fs.Visit(func(f *flag.Flag) {
+		if slices.Contains(names, f.Name) {
+			source = SourceArgument
+		}
+	})
+
+	return source
+}
+
+func (e *entry) isAlias(name string) bool {
+	return slices.Contains(e.aliases, name)
+}
+
+type registry struct {
+	names      map[string]*entry
+	warnedEnvs sync.Map
+	entries    []*entry
+	mutex      sync.RWMutex
+}
+
+// registries are weakly keyed by their FlagSet, and removed once it's garbage collected.
+var (
+	registries      = make(map[weak.Pointer[flag.FlagSet]]*registry)
+	registriesMutex sync.Mutex
+)
+
+func getRegistry(fs *flag.FlagSet) *registry {
+	key := weak.Make(fs)
+
+	registriesMutex.Lock()
+	defer registriesMutex.Unlock()
+
+	output, ok := registries[key]
+	if !ok {
+		output = &registry{names: make(map[string]*entry)}
+		registries[key] = output
+
+		runtime.AddCleanup(fs, removeRegistry, key)
+	}
+
+	return output
+}
+
+func removeRegistry(key weak.Pointer[flag.FlagSet]) {
+	registriesMutex.Lock()
+	defer registriesMutex.Unlock()
+
+	delete(registries, key)
+}
+
+func (r *registry) add(item *entry) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.entries = append(r.entries, item)
+
+	r.names[item.name] = item
+	if len(item.shorthand) > 0 {
+		r.names[item.shorthand] = item
+	}
+
+	for _, alias := range item.aliases {
+		r.names[alias] = item
+	}
+}
+
+func (r *registry) get(name string) (*entry, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	output, ok := r.names[name]
+
+	return output, ok
+}
+
+func (r *registry) all() []*entry {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return slices.Clone(r.entries)
+}
+
+// Validate returns the errors encountered while reading environment variables of the FlagSet's flags, whose default value has been kept,
+// and the errors of default values that are still in use, e.g. a missing default file.
+func Validate(fs *flag.FlagSet) error {
+	var errs []error
+
+	for _, item := range getRegistry(fs).all() {
+		if item.err != nil {
+			errs = append(errs, item.err)
+		}
+
+		if item.defaultErr != nil {
+			if source := item.currentSource(fs); source == SourceDefault || source == SourceOverride {
+				errs = append(errs, item.defaultErr)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package flags
+
+import (
+	"flag"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func registriesCount() int {
+	registriesMutex.Lock()
+	defer registriesMutex.Unlock()
+
+	return len(registries)
+}
+
+func TestRegistryCleanup(t *testing.T) {
+	before := registriesCount()
+
+	func() {
+		fs := flag.NewFlagSet("Cleanup", flag.ContinueOnError)
+		New("name", "Name").String(fs, "", nil)
+
+		if count := registriesCount(); count != before+1 {
+			t.Fatalf("registries = %d, want %d", count, before+1)
+		}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for registriesCount() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("registry of collected FlagSet is still referenced")
+		}
+
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+}
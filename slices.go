@@ -3,165 +3,399 @@ package flags
 import (
 	"flag"
 	"fmt"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
-type stringSlice struct {
-	values *[]string
+// MergePolicy defines how values of a slice flag from default, environment variable and arguments are combined.
+type MergePolicy int
+
+const (
+	// MergeReplace replaces the default by the environment variable, and both by the arguments.
+	MergeReplace MergePolicy = iota
+	// MergeAppendDefault appends the environment variable to the default, then the arguments.
+	MergeAppendDefault
+	// MergeAppendEnv replaces the default by the environment variable, then appends the arguments.
+	MergeAppendEnv
+	// MergeUnion appends like MergeAppendDefault, but skips values already present.
+	MergeUnion
+)
+
+// listSeparator joins the elements of slice and map flags in their rendering.
+const listSeparator = ", "
+
+type sliceValue[T any] struct {
+	values *[]T
+	parse  func(string) (T, error)
+	format func(T) string
+	equal  func(T, T) bool
+	split  func(string) ([]string, error)
+	merge  MergePolicy
 	edited bool
 }
 
-func newStringSlice(val []string, p *[]string) *stringSlice {
+func newSliceValue[T any](val []T, p *[]T, parse func(string) (T, error), format func(T) string, equal func(T, T) bool, split func(string) ([]string, error), merge MergePolicy) *sliceValue[T] {
 	*p = val
 
-	return &stringSlice{values: p}
+	return &sliceValue[T]{
+		values: p,
+		parse:  parse,
+		format: format,
+		equal:  equal,
+		split:  split,
+		merge:  merge,
+	}
 }
 
-func (i *stringSlice) String() string {
+func equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+func (i *sliceValue[T]) String() string {
 	if i == nil || i.values == nil || len(*i.values) == 0 {
 		return ""
 	}
 
-	return "[" + strings.Join(*i.values, ", ") + "]"
+	var builder strings.Builder
+	for _, value := range *i.values {
+		if builder.Len() != 0 {
+			builder.WriteString(listSeparator)
+		}
+
+		builder.WriteString(i.format(value))
+	}
+
+	return "[" + builder.String() + "]"
 }
 
-func (i *stringSlice) Get() any {
+func (i *sliceValue[T]) Get() any {
 	return *i.values
 }
 
-func (i *stringSlice) Set(value string) error {
+func (i *sliceValue[T]) Set(value string) error {
 	if !i.edited {
 		i.edited = true
 
-		*i.values = (*i.values)[:0]
+		// the default's backing array belongs to the caller, so it's never appended to
+		if i.merge == MergeReplace {
+			*i.values = nil
+		} else {
+			*i.values = slices.Clone(*i.values)
+		}
 	}
 
-	*i.values = append(*i.values, value)
+	if i.split == nil {
+		parsed, err := i.parse(value)
+		if err != nil {
+			return err
+		}
+
+		*i.values = i.add(*i.values, parsed)
+
+		return nil
+	}
+
+	parts, err := i.split(value)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		parsed, err := i.parse(part)
+		if err != nil {
+			return fmt.Errorf("parse `%s`: %w", part, err)
+		}
+
+		*i.values = i.add(*i.values, parsed)
+	}
 
 	return nil
 }
 
-// StringSlice creates a string slice flag.
-func StringSlice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []string, overrides []Override) *[]string {
-	output := new([]string)
+func (i *sliceValue[T]) add(values []T, value T) []T {
+	if i.merge == MergeUnion && slices.ContainsFunc(values, func(existing T) bool {
+		return i.equal(existing, value)
+	}) {
+		return values
+	}
+
+	return append(values, value)
+}
+
+// mergeEnv combines the default values with the ones from the environment variable.
+func (i *sliceValue[T]) mergeEnv(defaults, values []T) []T {
+	if i.merge == MergeReplace || i.merge == MergeAppendEnv {
+		return values
+	}
 
-	StringSliceVar(fs, output, prefix, docPrefix, name, shorthand, label, env, envSeparator, values, overrides)
+	output := slices.Clone(defaults)
+	for _, value := range values {
+		output = i.add(output, value)
+	}
 
 	return output
 }
 
-// StringSliceVar binds a string slice flag.
-func StringSliceVar(fs *flag.FlagSet, output *[]string, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []string, overrides []Override) {
-	flagName, envName := getNameAndEnv(fs, firstUpperCase(prefix), name, env)
-	usage := formatLabel(prefix, docPrefix, label, envName) + fmt.Sprintf(", as a `string slice`, environment variable separated by %q", envSeparator)
+// sliceVar binds a slice flag. Values are compared with equal to remove duplicates, or by their format when nil.
+func sliceVar[T any](b Builder, fs *flag.FlagSet, output *[]T, values []T, overrides []Override, typeName string, parse func(string) (T, error), format func(T) string, equal func(T, T) bool) {
+	flagName, envName, usage := b.computeDescription(fs)
+	usage += fmt.Sprintf(", as a `%s slice`, environment variable separated by %q", typeName, b.envSeparator)
 
-	initialValue := defaultValue(defaultStaticValue(name, values, overrides), envName, func(input string) ([]string, error) {
-		if len(input) == 0 {
-			return []string{}, nil
+	var split func(string) ([]string, error)
+
+	if b.quoted {
+		usage += ", with double quotes and backslash escaping"
+
+		split = func(input string) ([]string, error) {
+			return splitQuoted(input, b.envSeparator)
 		}
 
-		return strings.Split(input, envSeparator), nil
-	})
+		formatValue := format
+		format = func(value T) string {
+			return quote(formatValue(value), b.envSeparator, listSeparator)
+		}
+	}
 
-	targetOutput := newStringSlice(initialValue, output)
+	if b.indexedEnv {
+		usage += fmt.Sprintf(", or indexed environment variables ${%s_0}, ${%s_1}...", envName, envName)
+	}
 
-	if len(shorthand) > 0 {
-		fs.Var(targetOutput, firstLowerCase(prefix+firstUpperCase(shorthand)), usage)
+	if equal == nil {
+		equal = func(a, b T) bool {
+			return format(a) == format(b)
+		}
 	}
 
-	fs.Var(targetOutput, firstLowerCase(flagName), usage)
-}
+	defaults := defaultStaticValue(b.name, values, overrides)
+	target := newSliceValue(defaults, output, parse, format, equal, split, b.merge)
 
-type float64Slice struct {
-	values *[]float64
-	edited bool
-}
+	fs.Var(target, flagName, usage)
 
-func newfloat64Slice(val []float64, p *[]float64) *float64Slice {
-	*p = val
+	parseEnv := func(input string) ([]T, error) {
+		if len(input) == 0 {
+			return target.mergeEnv(defaults, []T{}), nil
+		}
 
-	return &float64Slice{values: p}
-}
+		parts, err := b.splitEnv(input)
+		if err != nil {
+			return nil, err
+		}
 
-func (i *float64Slice) String() string {
-	if i == nil || i.values == nil || len(*i.values) == 0 {
-		return ""
+		parsed, err := parseSlice(parts, parse)
+		if err != nil {
+			return nil, err
+		}
+
+		return target.mergeEnv(defaults, parsed), nil
 	}
 
-	var builder strings.Builder
-	for _, value := range *i.values {
-		if builder.Len() != 0 {
-			_, _ = fmt.Fprintf(&builder, ", ")
+	if !b.indexedEnv {
+		bind(b, fs, flagName, envName, usage, output, overrides, parseEnv)
+
+		return
+	}
+
+	lookup := func() (string, []T, bool, error) {
+		indexed, err := lookupIndexedEnv(envName)
+		if err != nil {
+			return "", nil, false, err
+		}
+
+		raw, ok := lookupEnv(fs, envName, b.deprecatedEnvs)
+
+		switch {
+		case ok && len(indexed) > 0:
+			return "", nil, false, fmt.Errorf("both `%s` and `%s_0` are set", envName, envName)
+
+		case ok:
+			parsed, err := parseEnv(raw)
+			return raw, parsed, true, err
+
+		case len(indexed) == 0:
+			return "", nil, false, nil
 		}
 
-		_, _ = fmt.Fprintf(&builder, "%f", value)
+		parsed, err := parseSlice(indexed, parse)
+		if err != nil {
+			return "", nil, false, err
+		}
+
+		return strings.Join(indexed, b.envSeparator), target.mergeEnv(defaults, parsed), true, nil
 	}
 
-	return "[" + builder.String() + "]"
+	// misconfigured indexed environment variables are easy to miss, so they are reported even if Validate is not called
+	bindEnv(b, fs, flagName, envName, usage, output, overrides, func() (string, []T, bool, error) {
+		raw, parsed, ok, err := lookup()
+		if err != nil {
+			getLogger().Warn("environment variable is ignored", "env", envName, "flag", flagName, "error", err)
+		}
+
+		return raw, parsed, ok, err
+	})
 }
 
-func (i *float64Slice) Get() any {
-	return *i.values
+func parseSlice[T any](parts []string, parse func(string) (T, error)) ([]T, error) {
+	items := make([]T, 0, len(parts))
+
+	for _, value := range parts {
+		parsed, err := parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("parse `%s`: %w", value, err)
+		}
+
+		items = append(items, parsed)
+	}
+
+	return items, nil
 }
 
-func (i *float64Slice) Set(value string) error {
-	if !i.edited {
-		i.edited = true
+func lookupIndexedEnv(envName string) ([]string, error) {
+	values := make(map[int]string)
+
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
 
-		*i.values = (*i.values)[:0]
+		suffix, ok := strings.CutPrefix(name, envName+"_")
+		if !ok {
+			continue
+		}
+
+		if index, err := strconv.Atoi(suffix); err == nil && index >= 0 && strconv.Itoa(index) == suffix {
+			values[index] = value
+		}
 	}
 
-	floatValue, err := strconv.ParseFloat(value, 64)
-	if err != nil {
-		return err
+	output := make([]string, len(values))
+
+	for index := range output {
+		value, ok := values[index]
+		if !ok {
+			return nil, fmt.Errorf("`%s_%d` is missing, indexes must be contiguous from 0", envName, index)
+		}
+
+		output[index] = value
 	}
 
-	*i.values = append(*i.values, floatValue)
+	return output, nil
+}
 
-	return nil
+// StringSlice creates a string slice flag.
+func StringSlice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []string, overrides []Override) *[]string {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).StringSlice(fs, values, overrides)
 }
 
-// Float64Slice creates a string slice flag.
-func Float64Slice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []float64, overrides []Override) *[]float64 {
-	output := new([]float64)
+// StringSliceVar binds a string slice flag.
+func StringSliceVar(fs *flag.FlagSet, output *[]string, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []string, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).StringSliceVar(fs, output, values, overrides)
+}
 
-	Float64SliceVar(fs, output, prefix, docPrefix, name, shorthand, label, env, envSeparator, values, overrides)
+func (b Builder) StringSliceVar(fs *flag.FlagSet, output *[]string, values []string, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "string", func(input string) (string, error) {
+		return input, nil
+	}, func(value string) string {
+		return value
+	}, equal[string])
+}
 
-	return output
+// Float64Slice creates a string slice flag.
+func Float64Slice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []float64, overrides []Override) *[]float64 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).Float64Slice(fs, values, overrides)
 }
 
 // Float64SliceVar binds a string slice flag.
 func Float64SliceVar(fs *flag.FlagSet, output *[]float64, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []float64, overrides []Override) {
-	flagName, envName := getNameAndEnv(fs, firstUpperCase(prefix), name, env)
-	usage := formatLabel(prefix, docPrefix, label, envName) + fmt.Sprintf(", as a `float64 slice`, environment variable separated by %q", envSeparator)
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).Float64SliceVar(fs, output, values, overrides)
+}
 
-	initialValue := defaultValue(defaultStaticValue(name, values, overrides), envName, func(input string) ([]float64, error) {
-		if len(input) == 0 {
-			return []float64{}, nil
-		}
+func (b Builder) Float64SliceVar(fs *flag.FlagSet, output *[]float64, values []float64, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "float64", func(input string) (float64, error) {
+		return strconv.ParseFloat(input, 64)
+	}, func(value float64) string {
+		return fmt.Sprintf("%f", value)
+	}, equal[float64])
+}
 
-		parts := strings.Split(input, envSeparator)
+// IntSlice creates an int slice flag.
+func IntSlice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []int, overrides []Override) *[]int {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).IntSlice(fs, values, overrides)
+}
 
-		var output []float64
+// IntSliceVar binds an int slice flag.
+func IntSliceVar(fs *flag.FlagSet, output *[]int, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []int, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).IntSliceVar(fs, output, values, overrides)
+}
 
-		for _, value := range parts {
-			floatValue, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				return nil, fmt.Errorf("parse `%s`: %w", value, err)
-			}
+func (b Builder) IntSliceVar(fs *flag.FlagSet, output *[]int, values []int, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "int", func(input string) (int, error) {
+		intVal, err := strconv.ParseInt(input, 0, strconv.IntSize)
+		return int(intVal), err
+	}, strconv.Itoa, equal[int])
+}
 
-			output = append(output, floatValue)
-		}
+// Int64Slice creates an int64 slice flag.
+func Int64Slice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []int64, overrides []Override) *[]int64 {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).Int64Slice(fs, values, overrides)
+}
 
-		return output, nil
-	})
+// Int64SliceVar binds an int64 slice flag.
+func Int64SliceVar(fs *flag.FlagSet, output *[]int64, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []int64, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).Int64SliceVar(fs, output, values, overrides)
+}
 
-	targetOutput := newfloat64Slice(initialValue, output)
+func (b Builder) Int64SliceVar(fs *flag.FlagSet, output *[]int64, values []int64, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "int64", func(input string) (int64, error) {
+		return strconv.ParseInt(input, 0, 64)
+	}, func(value int64) string {
+		return strconv.FormatInt(value, 10)
+	}, equal[int64])
+}
 
-	if len(shorthand) > 0 {
-		fs.Var(targetOutput, firstLowerCase(prefix+firstUpperCase(shorthand)), usage)
-	}
+// UintSlice creates an uint slice flag.
+func UintSlice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []uint, overrides []Override) *[]uint {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).UintSlice(fs, values, overrides)
+}
+
+// UintSliceVar binds an uint slice flag.
+func UintSliceVar(fs *flag.FlagSet, output *[]uint, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []uint, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).UintSliceVar(fs, output, values, overrides)
+}
+
+func (b Builder) UintSliceVar(fs *flag.FlagSet, output *[]uint, values []uint, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "uint", func(input string) (uint, error) {
+		intVal, err := strconv.ParseUint(input, 0, strconv.IntSize)
+		return uint(intVal), err
+	}, func(value uint) string {
+		return strconv.FormatUint(uint64(value), 10)
+	}, equal[uint])
+}
+
+// DurationSlice creates a duration slice flag.
+func DurationSlice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []time.Duration, overrides []Override) *[]time.Duration {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).DurationSlice(fs, values, overrides)
+}
+
+// DurationSliceVar binds a duration slice flag.
+func DurationSliceVar(fs *flag.FlagSet, output *[]time.Duration, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []time.Duration, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).DurationSliceVar(fs, output, values, overrides)
+}
+
+func (b Builder) DurationSliceVar(fs *flag.FlagSet, output *[]time.Duration, values []time.Duration, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "duration", time.ParseDuration, time.Duration.String, equal[time.Duration])
+}
+
+// BoolSlice creates a bool slice flag.
+func BoolSlice(fs *flag.FlagSet, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []bool, overrides []Override) *[]bool {
+	return newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).BoolSlice(fs, values, overrides)
+}
+
+// BoolSliceVar binds a bool slice flag.
+func BoolSliceVar(fs *flag.FlagSet, output *[]bool, prefix, docPrefix, name, shorthand, label, env, envSeparator string, values []bool, overrides []Override) {
+	newBuilder(prefix, docPrefix, name, shorthand, label, env, envSeparator).BoolSliceVar(fs, output, values, overrides)
+}
 
-	fs.Var(targetOutput, firstLowerCase(flagName), usage)
+func (b Builder) BoolSliceVar(fs *flag.FlagSet, output *[]bool, values []bool, overrides []Override) {
+	sliceVar(b, fs, output, values, overrides, "bool", strconv.ParseBool, strconv.FormatBool, equal[bool])
 }
@@ -2,8 +2,10 @@ package flags_test
 
 import (
 	"flag"
+	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ViBiOh/flags"
 	"github.com/stretchr/testify/assert"
@@ -60,6 +62,26 @@ func TestStringSlice(t *testing.T) {
 			[]string{"system", "default"},
 			"Usage of StringSlice:\n  -n, --namespace  string slice  Namespace ${STRING_SLICE_NAMESPACE}, as a string slice, environment variable separated by \",\" (default [default])\n",
 		},
+		"with quoted": {
+			flags.New("patterns", "Patterns").Quoted(),
+			func() {
+				t.Setenv("STRING_SLICE_PATTERNS", `"^a{1,2}$",b\,c`)
+			},
+			args{
+				args: []string{"--patterns", `"x,y",z`, "--patterns", `say "hi"`},
+			},
+			[]string{"x,y", "z", `say "hi"`},
+			"Usage of StringSlice:\n  --patterns  string slice  Patterns ${STRING_SLICE_PATTERNS}, as a string slice, environment variable separated by \",\", with double quotes and backslash escaping (default [\"^a{1,2}$\", \"b,c\"])\n",
+		},
+		"with quoted and custom separator": {
+			flags.New("selectors", "Selectors").Quoted().EnvSeparator(";"),
+			nil,
+			args{
+				defaultValue: []string{"a, b", "c;d", "e"},
+			},
+			[]string{"a, b", "c;d", "e"},
+			"Usage of StringSlice:\n  --selectors  string slice  Selectors ${STRING_SLICE_SELECTORS}, as a string slice, environment variable separated by \";\", with double quotes and backslash escaping (default [\"a, b\", \"c;d\", e])\n",
+		},
 		"with env": {
 			flags.New("match", "Match").Env("MATCHES"),
 			func() {
@@ -180,3 +202,497 @@ func TestFloat64Slice(t *testing.T) {
 		})
 	}
 }
+
+func TestIntSlice(t *testing.T) {
+	type args struct {
+		defaultValue []int
+		overrides    []flags.Override
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      []int
+		wantUsage string
+	}{
+		"with default value": {
+			flags.New("ids", "Ids"),
+			nil,
+			args{
+				defaultValue: []int{1, 2},
+			},
+			[]int{1, 2},
+			"Usage of IntSlice:\n  --ids  int slice  Ids ${INT_SLICE_IDS}, as a int slice, environment variable separated by \",\" (default [1, 2])\n",
+		},
+		"with read from environment variable": {
+			flags.New("ids", "Ids").Env("INT_SLICE_IDS_FROM_ENV"),
+			func() {
+				t.Setenv("INT_SLICE_IDS_FROM_ENV", "3,0x10")
+			},
+			args{
+				defaultValue: []int{1, 2},
+			},
+			[]int{3, 16},
+			"Usage of IntSlice:\n  --ids  int slice  Ids ${INT_SLICE_IDS_FROM_ENV}, as a int slice, environment variable separated by \",\" (default [3, 16])\n",
+		},
+		"with args": {
+			flags.New("ids", "Ids"),
+			nil,
+			args{
+				defaultValue: []int{1, 2},
+				args:         []string{"--ids", "4", "--ids", "5"},
+			},
+			[]int{4, 5},
+			"Usage of IntSlice:\n  --ids  int slice  Ids ${INT_SLICE_IDS}, as a int slice, environment variable separated by \",\" (default [1, 2])\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("IntSlice", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.IntSlice(fs, testCase.args.defaultValue, testCase.args.overrides)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestInt64Slice(t *testing.T) {
+	type args struct {
+		defaultValue []int64
+		overrides    []flags.Override
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      []int64
+		wantUsage string
+	}{
+		"with default value": {
+			flags.New("ids", "Ids"),
+			nil,
+			args{
+				defaultValue: []int64{1, 2},
+			},
+			[]int64{1, 2},
+			"Usage of Int64Slice:\n  --ids  int64 slice  Ids ${INT64_SLICE_IDS}, as a int64 slice, environment variable separated by \",\" (default [1, 2])\n",
+		},
+		"with read from environment variable": {
+			flags.New("ids", "Ids").Env("INT64_SLICE_IDS_FROM_ENV"),
+			func() {
+				t.Setenv("INT64_SLICE_IDS_FROM_ENV", "3,-4")
+			},
+			args{
+				defaultValue: []int64{1, 2},
+			},
+			[]int64{3, -4},
+			"Usage of Int64Slice:\n  --ids  int64 slice  Ids ${INT64_SLICE_IDS_FROM_ENV}, as a int64 slice, environment variable separated by \",\" (default [3, -4])\n",
+		},
+		"with args": {
+			flags.New("ids", "Ids"),
+			nil,
+			args{
+				defaultValue: []int64{1, 2},
+				args:         []string{"--ids", "4", "--ids", "5"},
+			},
+			[]int64{4, 5},
+			"Usage of Int64Slice:\n  --ids  int64 slice  Ids ${INT64_SLICE_IDS}, as a int64 slice, environment variable separated by \",\" (default [1, 2])\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("Int64Slice", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.Int64Slice(fs, testCase.args.defaultValue, testCase.args.overrides)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestUintSlice(t *testing.T) {
+	type args struct {
+		defaultValue []uint
+		overrides    []flags.Override
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      []uint
+		wantUsage string
+	}{
+		"with default value": {
+			flags.New("ports", "Ports"),
+			nil,
+			args{
+				defaultValue: []uint{80, 443},
+			},
+			[]uint{80, 443},
+			"Usage of UintSlice:\n  --ports  uint slice  Ports ${UINT_SLICE_PORTS}, as a uint slice, environment variable separated by \",\" (default [80, 443])\n",
+		},
+		"with read from environment variable": {
+			flags.New("ports", "Ports").Env("UINT_SLICE_PORTS_FROM_ENV"),
+			func() {
+				t.Setenv("UINT_SLICE_PORTS_FROM_ENV", "8080,8443")
+			},
+			args{
+				defaultValue: []uint{80, 443},
+			},
+			[]uint{8080, 8443},
+			"Usage of UintSlice:\n  --ports  uint slice  Ports ${UINT_SLICE_PORTS_FROM_ENV}, as a uint slice, environment variable separated by \",\" (default [8080, 8443])\n",
+		},
+		"with args": {
+			flags.New("ports", "Ports"),
+			nil,
+			args{
+				defaultValue: []uint{80, 443},
+				args:         []string{"--ports", "1080"},
+			},
+			[]uint{1080},
+			"Usage of UintSlice:\n  --ports  uint slice  Ports ${UINT_SLICE_PORTS}, as a uint slice, environment variable separated by \",\" (default [80, 443])\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("UintSlice", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.UintSlice(fs, testCase.args.defaultValue, testCase.args.overrides)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestDurationSlice(t *testing.T) {
+	type args struct {
+		defaultValue []time.Duration
+		overrides    []flags.Override
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      []time.Duration
+		wantUsage string
+	}{
+		"with default value": {
+			flags.New("backoffs", "Backoffs"),
+			nil,
+			args{
+				defaultValue: []time.Duration{time.Second},
+			},
+			[]time.Duration{time.Second},
+			"Usage of DurationSlice:\n  --backoffs  duration slice  Backoffs ${DURATION_SLICE_BACKOFFS}, as a duration slice, environment variable separated by \",\" (default [1s])\n",
+		},
+		"with read from environment variable": {
+			flags.New("backoffs", "Backoffs").Env("DURATION_SLICE_BACKOFFS_FROM_ENV"),
+			func() {
+				t.Setenv("DURATION_SLICE_BACKOFFS_FROM_ENV", "1m,1h")
+			},
+			args{
+				defaultValue: []time.Duration{time.Second},
+			},
+			[]time.Duration{time.Minute, time.Hour},
+			"Usage of DurationSlice:\n  --backoffs  duration slice  Backoffs ${DURATION_SLICE_BACKOFFS_FROM_ENV}, as a duration slice, environment variable separated by \",\" (default [1m0s, 1h0m0s])\n",
+		},
+		"with args": {
+			flags.New("backoffs", "Backoffs"),
+			nil,
+			args{
+				defaultValue: []time.Duration{time.Second},
+				args:         []string{"--backoffs", "30s"},
+			},
+			[]time.Duration{30 * time.Second},
+			"Usage of DurationSlice:\n  --backoffs  duration slice  Backoffs ${DURATION_SLICE_BACKOFFS}, as a duration slice, environment variable separated by \",\" (default [1s])\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("DurationSlice", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.DurationSlice(fs, testCase.args.defaultValue, testCase.args.overrides)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestBoolSlice(t *testing.T) {
+	type args struct {
+		defaultValue []bool
+		overrides    []flags.Override
+		args         []string
+	}
+
+	cases := map[string]struct {
+		builder   flags.Builder
+		preTest   func()
+		args      args
+		want      []bool
+		wantUsage string
+	}{
+		"with default value": {
+			flags.New("toggles", "Toggles"),
+			nil,
+			args{
+				defaultValue: []bool{true},
+			},
+			[]bool{true},
+			"Usage of BoolSlice:\n  --toggles  bool slice  Toggles ${BOOL_SLICE_TOGGLES}, as a bool slice, environment variable separated by \",\" (default [true])\n",
+		},
+		"with read from environment variable": {
+			flags.New("toggles", "Toggles").Env("BOOL_SLICE_TOGGLES_FROM_ENV"),
+			func() {
+				t.Setenv("BOOL_SLICE_TOGGLES_FROM_ENV", "false,true")
+			},
+			args{
+				defaultValue: []bool{true},
+			},
+			[]bool{false, true},
+			"Usage of BoolSlice:\n  --toggles  bool slice  Toggles ${BOOL_SLICE_TOGGLES_FROM_ENV}, as a bool slice, environment variable separated by \",\" (default [false, true])\n",
+		},
+		"with args": {
+			flags.New("toggles", "Toggles"),
+			nil,
+			args{
+				defaultValue: []bool{true},
+				args:         []string{"--toggles", "false"},
+			},
+			[]bool{false},
+			"Usage of BoolSlice:\n  --toggles  bool slice  Toggles ${BOOL_SLICE_TOGGLES}, as a bool slice, environment variable separated by \",\" (default [true])\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("BoolSlice", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			if testCase.preTest != nil {
+				testCase.preTest()
+			}
+
+			got := testCase.builder.BoolSlice(fs, testCase.args.defaultValue, testCase.args.overrides)
+			fs.Usage()
+
+			assert.NoError(t, fs.Parse(testCase.args.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestIndexedEnv(t *testing.T) {
+	cases := map[string]struct {
+		preTest func(*testing.T)
+		want    []string
+		wantErr string
+		wantLog string
+	}{
+		"indexed": {
+			func(t *testing.T) {
+				t.Setenv("INDEXED_ENV_HEADER_0", "X-User: bob")
+				t.Setenv("INDEXED_ENV_HEADER_1", "X-Auth: a,b")
+			},
+			[]string{"X-User: bob", "X-Auth: a,b"},
+			"",
+			"",
+		},
+		"separated": {
+			func(t *testing.T) {
+				t.Setenv("INDEXED_ENV_HEADER", "X-User: bob,X-Auth: key")
+			},
+			[]string{"X-User: bob", "X-Auth: key"},
+			"",
+			"",
+		},
+		"gap": {
+			func(t *testing.T) {
+				t.Setenv("INDEXED_ENV_HEADER_0", "X-User: bob")
+				t.Setenv("INDEXED_ENV_HEADER_2", "X-Auth: key")
+			},
+			[]string{"default"},
+			"env `INDEXED_ENV_HEADER` of flag `header`: `INDEXED_ENV_HEADER_1` is missing, indexes must be contiguous from 0",
+			"level=WARN msg=\"environment variable is ignored\" env=INDEXED_ENV_HEADER flag=header error=\"`INDEXED_ENV_HEADER_1` is missing, indexes must be contiguous from 0\"\n",
+		},
+		"both": {
+			func(t *testing.T) {
+				t.Setenv("INDEXED_ENV_HEADER", "X-User: bob")
+				t.Setenv("INDEXED_ENV_HEADER_0", "X-Auth: key")
+			},
+			[]string{"default"},
+			"env `INDEXED_ENV_HEADER` of flag `header`: both `INDEXED_ENV_HEADER` and `INDEXED_ENV_HEADER_0` are set",
+			"level=WARN msg=\"environment variable is ignored\" env=INDEXED_ENV_HEADER flag=header error=\"both `INDEXED_ENV_HEADER` and `INDEXED_ENV_HEADER_0` are set\"\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			testCase.preTest(t)
+
+			var logs strings.Builder
+			flags.SetLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{
+				ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+					if attr.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return attr
+				},
+			})))
+			defer flags.SetLogger(nil)
+
+			fs := flag.NewFlagSet("IndexedEnv", flag.ContinueOnError)
+			got := flags.New("header", "Header").IndexedEnv().StringSlice(fs, []string{"default"}, nil)
+
+			assert.Equal(t, testCase.want, *got)
+
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, flags.Validate(fs), testCase.wantErr)
+			} else {
+				assert.NoError(t, flags.Validate(fs))
+			}
+
+			assert.Equal(t, testCase.wantLog, logs.String())
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	cases := map[string]struct {
+		merge flags.MergePolicy
+		env   string
+		args  []string
+		want  []string
+	}{
+		"replace": {
+			flags.MergeReplace,
+			"x-env",
+			[]string{"--header", "x-arg"},
+			[]string{"x-arg"},
+		},
+		"replace without env": {
+			flags.MergeReplace,
+			"",
+			[]string{"--header", "x-arg"},
+			[]string{"x-arg"},
+		},
+		"replace without args": {
+			flags.MergeReplace,
+			"x-env",
+			nil,
+			[]string{"x-env"},
+		},
+		"append to default": {
+			flags.MergeAppendDefault,
+			"x-env",
+			[]string{"--header", "x-arg"},
+			[]string{"x-default", "x-env", "x-arg"},
+		},
+		"append to default without env": {
+			flags.MergeAppendDefault,
+			"",
+			[]string{"--header", "x-arg"},
+			[]string{"x-default", "x-arg"},
+		},
+		"append to env": {
+			flags.MergeAppendEnv,
+			"x-env",
+			[]string{"--header", "x-arg"},
+			[]string{"x-env", "x-arg"},
+		},
+		"union": {
+			flags.MergeUnion,
+			"x-env,x-default",
+			[]string{"--header", "x-arg", "--header", "x-env"},
+			[]string{"x-default", "x-env", "x-arg"},
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			if len(testCase.env) > 0 {
+				t.Setenv("MERGE_HEADER", testCase.env)
+			}
+
+			fs := flag.NewFlagSet("Merge", flag.ContinueOnError)
+			defaults := []string{"x-default"}
+
+			got := flags.New("header", "Header").Merge(testCase.merge).StringSlice(fs, defaults, nil)
+
+			assert.NoError(t, fs.Parse(testCase.args))
+			assert.Equal(t, testCase.want, *got)
+			assert.Equal(t, []string{"x-default"}, defaults)
+		})
+	}
+}
+
+func TestMergeUnionFloat(t *testing.T) {
+	fs := flag.NewFlagSet("MergeFloat", flag.ContinueOnError)
+
+	got := flags.New("ratios", "Ratios").Merge(flags.MergeUnion).Float64Slice(fs, nil, nil)
+
+	assert.NoError(t, fs.Parse([]string{"--ratios", "0.0000001", "--ratios", "0.0000002", "--ratios", "1e-7"}))
+	assert.Equal(t, []float64{0.0000001, 0.0000002}, *got)
+}
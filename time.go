@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"errors"
+	"flag"
+	"time"
+)
+
+// DateLayout is the layout of Date flags.
+const DateLayout = time.DateOnly
+
+func parseTime(layouts []string) func(string) (time.Time, error) {
+	return func(input string) (time.Time, error) {
+		if len(input) == 0 {
+			return time.Time{}, nil
+		}
+
+		var errs []error
+
+		for _, layout := range layouts {
+			output, err := time.Parse(layout, input)
+			if err == nil {
+				return output, nil
+			}
+
+			errs = append(errs, err)
+		}
+
+		return time.Time{}, errors.Join(errs...)
+	}
+}
+
+func formatTime(layout string) func(time.Time) string {
+	return func(value time.Time) string {
+		if value.IsZero() {
+			return ""
+		}
+
+		return value.Format(layout)
+	}
+}
+
+// TimeVar binds a time flag, parsed with the given layouts in order, RFC 3339 when none. Usage renders the default value with the first layout.
+func (b Builder) TimeVar(fs *flag.FlagSet, output *time.Time, value time.Time, overrides []Override, layouts ...string) {
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339}
+	}
+
+	valueVar(b.typed("time"), fs, output, value, overrides, parseTime(layouts), formatTime(layouts[0]))
+}
+
+// DateVar binds a date flag, e.g. `2026-01-01`, parsed in UTC.
+func (b Builder) DateVar(fs *flag.FlagSet, output *time.Time, value time.Time, overrides []Override) {
+	valueVar(b.typed("date"), fs, output, value, overrides, parseTime([]string{DateLayout}), formatTime(DateLayout))
+}
+
+// parseLocation rejects empty input, that time.LoadLocation would load as UTC.
+func parseLocation(input string) (*time.Location, error) {
+	if len(input) == 0 {
+		return nil, errors.New("empty location, use `UTC` explicitly")
+	}
+
+	return time.LoadLocation(input)
+}
+
+func formatLocation(value *time.Location) string {
+	if value == nil {
+		return ""
+	}
+
+	return value.String()
+}
+
+// LocationVar binds a time zone flag, e.g. `Europe/Paris`, loaded with time.LoadLocation. An empty value is rejected, so it doesn't replace the default by UTC.
+func (b Builder) LocationVar(fs *flag.FlagSet, output **time.Location, value *time.Location, overrides []Override) {
+	valueVar(b.typed("location"), fs, output, value, overrides, parseLocation, formatLocation)
+}
@@ -0,0 +1,97 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime(t *testing.T) {
+	cases := map[string]struct {
+		layouts []string
+		args    []string
+		want    time.Time
+		wantErr string
+	}{
+		"rfc3339": {
+			nil,
+			[]string{"--since", "2026-01-01T10:00:00Z"},
+			time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			"",
+		},
+		"layouts": {
+			[]string{time.RFC3339, time.DateTime},
+			[]string{"--since", "2026-01-01 10:00:00"},
+			time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			"",
+		},
+		"invalid": {
+			nil,
+			[]string{"--since", "yesterday"},
+			time.Time{},
+			"invalid value \"yesterday\" for flag -since: parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\"",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("Job", flag.ContinueOnError)
+			fs.SetOutput(&strings.Builder{})
+
+			got := flags.New("since", "Start time").Time(fs, time.Time{}, nil, testCase.layouts...)
+
+			err := fs.Parse(testCase.args)
+			if len(testCase.wantErr) > 0 {
+				assert.EqualError(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, *got)
+		})
+	}
+}
+
+func TestDateAndLocation(t *testing.T) {
+	t.Setenv("JOB_TZ", "Europe/Paris")
+
+	fs := flag.NewFlagSet("Job", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	since := flags.New("since", "Start date").Date(fs, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), nil)
+	until := flags.New("until", "End time").Time(fs, time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC), nil, time.DateTime)
+	tz := flags.New("tz", "Time zone").Location(fs, time.UTC, nil)
+	fs.Usage()
+
+	assert.NoError(t, fs.Parse([]string{"--since", "2026-01-01"}))
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *since)
+	assert.Equal(t, time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC), *until)
+	assert.Equal(t, "Europe/Paris", (*tz).String())
+	assert.Equal(t, "Usage of Job:\n  --since  date      Start date ${JOB_SINCE} (default 2025-12-01)\n  --tz     location  Time zone ${JOB_TZ} (default Europe/Paris)\n  --until  time      End time ${JOB_UNTIL} (default 2025-12-31 23:00:00)\n", writer.String())
+
+	assert.EqualError(t, fs.Parse([]string{"--tz", "Mars/Olympus"}), "invalid value \"Mars/Olympus\" for flag -tz: unknown time zone Mars/Olympus")
+}
+
+func TestLocationEmpty(t *testing.T) {
+	t.Setenv("EMPTY_TZ", "")
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	fs := flag.NewFlagSet("Empty", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	tz := flags.New("tz", "Time zone").Location(fs, paris, nil)
+
+	assert.Equal(t, paris, *tz)
+	assert.EqualError(t, flags.Validate(fs), "env `EMPTY_TZ` of flag `tz`: empty location, use `UTC` explicitly")
+	assert.EqualError(t, fs.Parse([]string{"--tz", ""}), "invalid value \"\" for flag -tz: empty location, use `UTC` explicitly")
+	assert.Equal(t, paris, *tz)
+}
@@ -0,0 +1,93 @@
+package flags
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// URLConstraint validates a parsed URL.
+type URLConstraint func(*url.URL) error
+
+// AllowedSchemes restricts the URL's scheme to the given ones.
+func AllowedSchemes(schemes ...string) URLConstraint {
+	return func(value *url.URL) error {
+		if !slices.Contains(schemes, value.Scheme) {
+			return fmt.Errorf("scheme `%s` is not one of %q", value.Scheme, schemes)
+		}
+
+		return nil
+	}
+}
+
+// RequireHost rejects URL without host.
+func RequireHost() URLConstraint {
+	return func(value *url.URL) error {
+		if len(value.Host) == 0 {
+			return errors.New("host is required")
+		}
+
+		return nil
+	}
+}
+
+func parseURL(constraints []URLConstraint) func(string) (url.URL, error) {
+	return func(input string) (url.URL, error) {
+		if len(input) == 0 {
+			return url.URL{}, nil
+		}
+
+		value, err := url.Parse(input)
+		if err != nil {
+			// url.Error contains the raw input, with its password
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				return url.URL{}, fmt.Errorf("parse url: %w", urlErr.Err)
+			}
+
+			return url.URL{}, err
+		}
+
+		for _, constraint := range constraints {
+			if err := constraint(value); err != nil {
+				return url.URL{}, fmt.Errorf("url `%s`: %w", value.Redacted(), err)
+			}
+		}
+
+		return *value, nil
+	}
+}
+
+func formatURL(value url.URL) string {
+	return value.Redacted()
+}
+
+// redactURL redacts the password of a raw URL, or the whole value if it can't be parsed.
+func redactURL(input string) string {
+	value, err := url.Parse(input)
+	if err != nil {
+		return redacted
+	}
+
+	return value.Redacted()
+}
+
+// URLVar binds an URL flag, the password of userinfo being redacted from Usage, dumps and errors. The default value must satisfy the constraints.
+// The flag package quotes the raw argument in its own parse errors, so a password given by argument can't be kept out of them: pass it by environment variable.
+func (b Builder) URLVar(fs *flag.FlagSet, output *url.URL, value string, overrides []Override, constraints ...URLConstraint) {
+	b = b.typed("url")
+	b.redact = redactURL
+	parse := parseURL(constraints)
+
+	initialValue, err := parse(defaultStaticValue(b.name, value, overrides))
+	if err != nil {
+		panic(fmt.Sprintf("invalid default value of flag `%s`: %s", b.name, err))
+	}
+
+	flagName, envName, usage := b.computeDescription(fs)
+
+	fs.Var(newValue(initialValue, output, parse, formatURL), flagName, usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, parse)
+}
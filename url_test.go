@@ -0,0 +1,117 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURL(t *testing.T) {
+	cases := map[string]struct {
+		preTest   func(*testing.T)
+		args      []string
+		want      string
+		wantUsage string
+		wantErr   string
+	}{
+		"default": {
+			nil,
+			nil,
+			"postgres://localhost:5432/app",
+			"Usage of Database:\n  --url  url  Database url ${DATABASE_URL} (default postgres://localhost:5432/app)\n",
+			"",
+		},
+		"env redacted": {
+			func(t *testing.T) {
+				t.Setenv("DATABASE_URL", "postgres://admin:secret@db:5432/app")
+			},
+			nil,
+			"postgres://admin:secret@db:5432/app",
+			"Usage of Database:\n  --url  url  Database url ${DATABASE_URL} (default postgres://admin:xxxxx@db:5432/app)\n",
+			"",
+		},
+		"argument": {
+			nil,
+			[]string{"--url", "postgresql://replica/app"},
+			"postgresql://replica/app",
+			"Usage of Database:\n  --url  url  Database url ${DATABASE_URL} (default postgres://localhost:5432/app)\n",
+			"",
+		},
+		"invalid scheme": {
+			nil,
+			[]string{"--url", "mysql://admin:secret@db/app"},
+			"postgres://localhost:5432/app",
+			"Usage of Database:\n  --url  url  Database url ${DATABASE_URL} (default postgres://localhost:5432/app)\n",
+			"url `mysql://admin:xxxxx@db/app`: scheme `mysql` is not one of [\"postgres\" \"postgresql\"]",
+		},
+		"missing host": {
+			nil,
+			[]string{"--url", "postgres:///app"},
+			"postgres://localhost:5432/app",
+			"Usage of Database:\n  --url  url  Database url ${DATABASE_URL} (default postgres://localhost:5432/app)\n",
+			"url `postgres:///app`: host is required",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			if testCase.preTest != nil {
+				testCase.preTest(t)
+			}
+
+			fs := flag.NewFlagSet("Database", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			got := flags.New("url", "Database url").URL(fs, "postgres://localhost:5432/app", nil, flags.AllowedSchemes("postgres", "postgresql"), flags.RequireHost())
+			fs.Usage()
+			usage := writer.String()
+
+			err := fs.Parse(testCase.args)
+			if len(testCase.wantErr) > 0 {
+				assert.ErrorContains(t, err, testCase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, testCase.want, got.String())
+			assert.Equal(t, testCase.wantUsage, usage)
+		})
+	}
+}
+
+func TestURLEnvError(t *testing.T) {
+	t.Setenv("UPSTREAM_TARGET", "http://user:secret@[::1:80/")
+
+	fs := flag.NewFlagSet("Upstream", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+
+	got := flags.New("target", "Upstream").URL(fs, "", nil)
+
+	assert.Equal(t, "", got.String())
+	assert.EqualError(t, flags.Validate(fs), "env `UPSTREAM_TARGET` of flag `target`: parse url: missing ']' in host")
+	assert.NotContains(t, flags.Validate(fs).Error(), "secret")
+}
+
+func TestURLUsageValues(t *testing.T) {
+	t.Setenv("VALUES_URL", "postgres://user:s3cret@db:5432/app")
+	t.Setenv("VALUES_REPLICA", "postgres://user:s3cret@[::1/app")
+
+	fs := flag.NewFlagSet("Values", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs, flags.WithValues())
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	flags.New("url", "Database url").URL(fs, "", nil)
+	flags.New("replica", "Replica url").URL(fs, "", nil)
+	fs.Usage()
+
+	assert.Equal(t, "Usage of Values:\n  --replica  url  Replica url ${VALUES_REPLICA} (env \"*****\", value unset from default)\n  --url      url  Database url ${VALUES_URL} (env \"postgres://user:xxxxx@db:5432/app\", value postgres://user:xxxxx@db:5432/app from env)\n", writer.String())
+	assert.NotContains(t, writer.String(), "s3cret")
+}
@@ -3,15 +3,36 @@ package flags
 import (
 	"flag"
 	"fmt"
+	"io"
+	"slices"
 	"sort"
+	"strings"
 )
 
 type Flag struct {
 	flag      *flag.Flag
+	entry     *entry
 	name      string
 	shorthand string
 }
 
+func (f *Flag) unquoteUsage() (string, string) {
+	flagType, usage := flag.UnquoteUsage(f.flag)
+	if flagType == "value" && f.entry != nil && len(f.entry.typeName) > 0 {
+		flagType = f.entry.typeName
+	}
+
+	return flagType, usage
+}
+
+func (f *Flag) group() string {
+	if f.entry == nil {
+		return ""
+	}
+
+	return f.entry.group
+}
+
 func (f *Flag) AddName(name string) {
 	if len(name) < len(f.flag.Name) {
 		f.shorthand = name
@@ -21,11 +42,89 @@ func (f *Flag) AddName(name string) {
 	}
 }
 
-func Usage(fs *flag.FlagSet) func() {
+type usageConfig struct {
+	descriptions map[string]string
+	order        []string
+	width        int
+	grouped      bool
+	deprecated   bool
+	hidden       bool
+	values       bool
+}
+
+// UsageOption configures the Usage output.
+type UsageOption func(*usageConfig)
+
+// WithDeprecated adds a section listing deprecated flags and environment variables with their replacement.
+func WithDeprecated() UsageOption {
+	return func(config *usageConfig) {
+		config.deprecated = true
+	}
+}
+
+// WithHidden includes flags declared as hidden.
+func WithHidden() UsageOption {
+	return func(config *usageConfig) {
+		config.hidden = true
+	}
+}
+
+// WithGroups renders one section per prefix or doc prefix. Sections are ordered by the given order, then by registration.
+func WithGroups(order ...string) UsageOption {
+	return func(config *usageConfig) {
+		config.grouped = true
+		config.order = order
+	}
+}
+
+// WithGroupDescription adds a description to the header of a group's section.
+func WithGroupDescription(group, description string) UsageOption {
+	return func(config *usageConfig) {
+		if config.descriptions == nil {
+			config.descriptions = make(map[string]string)
+		}
+
+		config.descriptions[group] = description
+	}
+}
+
+// WithWidth wraps descriptions to the given width, instead of detecting it from the terminal.
+func WithWidth(width int) UsageOption {
+	return func(config *usageConfig) {
+		config.width = width
+	}
+}
+
+// WithValues shows the static default, the environment variable and the effective value with its source of each flag.
+func WithValues() UsageOption {
+	return func(config *usageConfig) {
+		config.values = true
+	}
+}
+
+type usageWidths struct {
+	name      int
+	shorthand int
+	flagType  int
+	line      int
+}
+
+func Usage(fs *flag.FlagSet, options ...UsageOption) func() {
+	var config usageConfig
+	for _, option := range options {
+		option(&config)
+	}
+
 	return func() {
 		flags := make(map[string]*Flag)
+		registry := getRegistry(fs)
 
 		fs.VisitAll(func(f *flag.Flag) {
+			item, registered := registry.get(f.Name)
+			if registered && (item.isAlias(f.Name) || item.hidden && !config.hidden) {
+				return
+			}
+
 			usageSha := Sha(f.Usage)
 
 			if exist, ok := flags[usageSha]; ok {
@@ -35,17 +134,20 @@ func Usage(fs *flag.FlagSet) func() {
 					name: f.Name,
 					flag: f,
 				}
+
+				if registered {
+					flags[usageSha].entry = item
+				}
 			}
 		})
 
-		var (
-			maxTypeLen      int
-			maxNameLen      int
-			maxShorthandLen int
-		)
-
 		output := fs.Output()
 
+		widths := usageWidths{line: config.width}
+		if widths.line == 0 {
+			widths.line = terminalWidth(output)
+		}
+
 		if name := fs.Name(); len(name) > 0 {
 			_, _ = fmt.Fprintf(output, "Usage of %s:\n", fs.Name())
 		} else {
@@ -62,42 +164,210 @@ func Usage(fs *flag.FlagSet) func() {
 			copy(items[index+1:], items[index:])
 			items[index] = item
 
-			if length := len(item.name); length > maxNameLen {
-				maxNameLen = length
+			if length := len(item.name); length > widths.name {
+				widths.name = length
 			}
 
-			if length := len(item.shorthand); length > maxShorthandLen {
-				maxShorthandLen = length
+			if length := len(item.shorthand); length > widths.shorthand {
+				widths.shorthand = length
 			}
 
-			flagType, _ := flag.UnquoteUsage(item.flag)
-			if length := len(flagType); length > maxTypeLen {
-				maxTypeLen = length
+			flagType, _ := item.unquoteUsage()
+			if length := len(flagType); length > widths.flagType {
+				widths.flagType = length
 			}
 		}
 
-		if maxShorthandLen > 0 {
-			maxShorthandLen += 3
+		if widths.shorthand > 0 {
+			widths.shorthand += 3
 		}
 
-		for _, item := range items {
-			flagType, usage := flag.UnquoteUsage(item.flag)
+		printer := usagePrinter{
+			output:   output,
+			fs:       fs,
+			registry: registry,
+			config:   config,
+			widths:   widths,
+		}
 
-			if len(item.shorthand) > 0 {
-				_, _ = fmt.Fprintf(output, fmt.Sprintf("  %%-%ds--%%-%ds  %%-%ds  %%s", maxShorthandLen, maxNameLen, maxTypeLen), fmt.Sprintf("-%s, ", item.shorthand), item.name, flagType, usage)
-			} else {
-				_, _ = fmt.Fprintf(output, fmt.Sprintf("  %%-%ds--%%-%ds  %%-%ds  %%s", maxShorthandLen, maxNameLen, maxTypeLen), "", item.name, flagType, usage)
-			}
+		if config.grouped {
+			printer.printGroups(items)
+		} else {
+			printer.printFlags(items, "")
+		}
 
-			if defaultValue := item.flag.DefValue; len(defaultValue) > 0 {
-				if flagType == "string" {
-					_, _ = fmt.Fprintf(output, " (default %q)", defaultValue)
-				} else {
-					_, _ = fmt.Fprintf(output, " (default %v)", defaultValue)
-				}
-			}
+		if config.deprecated {
+			printer.printDeprecated()
+		}
+	}
+}
+
+type usagePrinter struct {
+	output   io.Writer
+	fs       *flag.FlagSet
+	registry *registry
+	config   usageConfig
+	widths   usageWidths
+}
+
+func (p usagePrinter) printGroups(items []*Flag) {
+	groups := make(map[string][]*Flag)
+	for _, item := range items {
+		groups[item.group()] = append(groups[item.group()], item)
+	}
+
+	p.printFlags(groups[""], "")
+	separator := len(groups[""]) > 0
+
+	order := slices.Clone(p.config.order)
+	for _, item := range p.registry.all() {
+		if !slices.Contains(order, item.group) {
+			order = append(order, item.group)
+		}
+	}
+
+	for _, group := range order {
+		groupItems := groups[group]
+		if len(group) == 0 || len(groupItems) == 0 {
+			continue
+		}
+
+		if separator {
+			_, _ = fmt.Fprint(p.output, "\n")
+		}
+		separator = true
+
+		if description := p.config.descriptions[group]; len(description) > 0 {
+			_, _ = fmt.Fprintf(p.output, "[%s] %s:\n", group, description)
+		} else {
+			_, _ = fmt.Fprintf(p.output, "[%s]:\n", group)
+		}
+
+		p.printFlags(groupItems, "["+group+"] ")
+	}
+}
+
+func (p usagePrinter) printFlags(items []*Flag, trimPrefix string) {
+	widths := p.widths
+	indent := 2 + widths.shorthand + 2 + widths.name + 2 + widths.flagType + 2
+
+	for _, item := range items {
+		flagType, usage := item.unquoteUsage()
+		usage = strings.TrimPrefix(usage, trimPrefix)
+
+		if p.config.values {
+			usage += p.describeValues(item, flagType)
+		} else if defaultValue := item.flag.DefValue; len(defaultValue) > 0 {
+			usage += fmt.Sprintf(" (default %s)", formatValue(flagType, defaultValue, item.entry != nil && item.entry.sensitive))
+		}
 
-			_, _ = fmt.Fprint(output, "\n")
+		usage = wrap(usage, indent, widths.line)
+
+		if len(item.shorthand) > 0 {
+			_, _ = fmt.Fprintf(p.output, fmt.Sprintf("  %%-%ds--%%-%ds  %%-%ds  %%s", widths.shorthand, widths.name, widths.flagType), fmt.Sprintf("-%s, ", item.shorthand), item.name, flagType, usage)
+		} else {
+			_, _ = fmt.Fprintf(p.output, fmt.Sprintf("  %%-%ds--%%-%ds  %%-%ds  %%s", widths.shorthand, widths.name, widths.flagType), "", item.name, flagType, usage)
 		}
+
+		_, _ = fmt.Fprint(p.output, "\n")
+	}
+}
+
+func (p usagePrinter) describeValues(item *Flag, flagType string) string {
+	defaultValue := item.flag.DefValue
+	source := SourceDefault
+
+	var (
+		envValue  string
+		envSet    bool
+		sensitive bool
+	)
+
+	if item.entry != nil {
+		defaultValue = item.entry.defaultValue
+		envValue = item.entry.envValue
+		envSet = item.entry.envSet
+		sensitive = item.entry.sensitive
+		source = item.entry.currentSource(p.fs)
+	} else if isSet(p.fs, item.name, item.shorthand) {
+		source = SourceArgument
+	}
+
+	var parts []string
+
+	if len(defaultValue) > 0 {
+		parts = append(parts, "default "+formatValue(flagType, defaultValue, sensitive))
+	}
+
+	if envSet {
+		parts = append(parts, "env "+formatValue("string", envValue, sensitive))
+	}
+
+	value := formatValue(flagType, item.flag.Value.String(), sensitive)
+	if len(value) == 0 {
+		value = "unset"
+	}
+
+	parts = append(parts, fmt.Sprintf("value %s from %s", value, source))
+
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+func formatValue(flagType, value string, sensitive bool) string {
+	if sensitive && len(value) > 0 {
+		return redacted
+	}
+
+	if flagType == "string" {
+		return fmt.Sprintf("%q", value)
+	}
+
+	return value
+}
+
+func isSet(fs *flag.FlagSet, names ...string) bool {
+	var output bool
+
+	fs.Visit(func(f *flag.Flag) {
+		if slices.Contains(names, f.Name) {
+			output = true
+		}
+	})
+
+	return output
+}
+
+func (p usagePrinter) printDeprecated() {
+	var names, replacements []string
+
+	for _, item := range p.registry.all() {
+		if item.hidden && !p.config.hidden {
+			continue
+		}
+
+		for _, alias := range item.aliases {
+			names = append(names, "--"+alias)
+			replacements = append(replacements, "--"+item.name)
+		}
+
+		for _, env := range item.deprecatedEnvs {
+			names = append(names, "${"+env+"}")
+			replacements = append(replacements, "${"+item.env+"}")
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	var maxNameLen int
+	for _, name := range names {
+		maxNameLen = max(maxNameLen, len(name))
+	}
+
+	_, _ = fmt.Fprint(p.output, "Deprecated:\n")
+
+	for index, name := range names {
+		_, _ = fmt.Fprintf(p.output, "  %-*s  use %s instead\n", maxNameLen, name, replacements[index])
 	}
 }
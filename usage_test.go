@@ -0,0 +1,100 @@
+package flags_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ViBiOh/flags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageGroups(t *testing.T) {
+	cases := map[string]struct {
+		options   []flags.UsageOption
+		wantUsage string
+	}{
+		"flat": {
+			nil,
+			"Usage of Groups:\n  -n,        --name         string  [db] Database name ${GROUPS_NAME}\n             --pprof                Enable pprof ${GROUPS_PPROF} (default false)\n  -replicaN, --replicaName  string  [replica] Database name ${GROUPS_REPLICA_NAME}\n  -u,        --url          string  [db] Database url ${GROUPS_URL}\n",
+		},
+		"by registration": {
+			[]flags.UsageOption{flags.WithGroups()},
+			"Usage of Groups:\n             --pprof                Enable pprof ${GROUPS_PPROF} (default false)\n\n[db]:\n  -n,        --name         string  Database name ${GROUPS_NAME}\n  -u,        --url          string  Database url ${GROUPS_URL}\n\n[replica]:\n  -replicaN, --replicaName  string  Database name ${GROUPS_REPLICA_NAME}\n",
+		},
+		"explicit order and description": {
+			[]flags.UsageOption{flags.WithGroups("replica"), flags.WithGroupDescription("db", "Primary database")},
+			"Usage of Groups:\n             --pprof                Enable pprof ${GROUPS_PPROF} (default false)\n\n[replica]:\n  -replicaN, --replicaName  string  Database name ${GROUPS_REPLICA_NAME}\n\n[db] Primary database:\n  -n,        --name         string  Database name ${GROUPS_NAME}\n  -u,        --url          string  Database url ${GROUPS_URL}\n",
+		},
+	}
+
+	for intention, testCase := range cases {
+		t.Run(intention, func(t *testing.T) {
+			fs := flag.NewFlagSet("Groups", flag.ContinueOnError)
+			fs.Usage = flags.Usage(fs, testCase.options...)
+
+			var writer strings.Builder
+			fs.SetOutput(&writer)
+
+			flags.New("url", "Database url").Shorthand("u").DocPrefix("db").String(fs, "", nil)
+			flags.New("name", "Database name").Shorthand("n").DocPrefix("db").String(fs, "", nil)
+			flags.New("name", "Database name").Shorthand("n").Prefix("replica").String(fs, "", nil)
+			fs.Bool("pprof", false, "Enable pprof ${GROUPS_PPROF}")
+			fs.Usage()
+
+			assert.Equal(t, testCase.wantUsage, writer.String())
+		})
+	}
+}
+
+func TestUsageWidth(t *testing.T) {
+	fs := flag.NewFlagSet("Width", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs, flags.WithWidth(60))
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	flags.New("address", "Listen address of the server, with its port").Shorthand("a").String(fs, "localhost:1080", nil)
+	flags.New("pprof", "Enable pprof").Bool(fs, false, nil)
+	fs.Usage()
+
+	assert.Equal(t, "Usage of Width:\n  -a, --address  string  Listen address of the server, with\n                         its port ${WIDTH_ADDRESS} (default\n                         \"localhost:1080\")\n      --pprof            Enable pprof ${WIDTH_PPROF}\n                         (default false)\n", writer.String())
+}
+
+func TestUsageValues(t *testing.T) {
+	t.Setenv("VALUES_NAME", "John")
+	t.Setenv("VALUES_PASSWORD", "secret")
+
+	fs := flag.NewFlagSet("Values", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs, flags.WithValues())
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	flags.New("name", "Name").String(fs, "Jane", nil)
+	flags.New("password", "Password").Sensitive().String(fs, "", nil)
+	flags.New("port", "Port").Shorthand("p").Uint(fs, 1080, nil)
+	flags.New("timeout", "Timeout").Duration(fs, 0, []flags.Override{flags.NewOverride("timeout", time.Second)})
+	flags.New("workers", "Workers").OptionalInt(fs, nil)
+
+	assert.NoError(t, fs.Parse([]string{"-p", "8080"}))
+	fs.Usage()
+
+	assert.Equal(t, "Usage of Values:\n      --name      string    Name ${VALUES_NAME} (default \"Jane\", env \"John\", value \"John\" from env)\n      --password  string    Password ${VALUES_PASSWORD} (env *****, value ***** from env)\n  -p, --port      uint      Port ${VALUES_PORT} (default 1080, value 8080 from argument)\n      --timeout   duration  Timeout ${VALUES_TIMEOUT} (default 1s, value 1s from override)\n      --workers   int       Workers ${VALUES_WORKERS} (value unset from default)\n", writer.String())
+}
+
+func TestUsageSensitive(t *testing.T) {
+	t.Setenv("SENSITIVE_PASSWORD", "secret")
+
+	fs := flag.NewFlagSet("Sensitive", flag.ContinueOnError)
+	fs.Usage = flags.Usage(fs)
+
+	var writer strings.Builder
+	fs.SetOutput(&writer)
+
+	flags.New("password", "Password").Sensitive().String(fs, "", nil)
+	fs.Usage()
+
+	assert.Equal(t, "Usage of Sensitive:\n  --password  string  Password ${SENSITIVE_PASSWORD} (default *****)\n", writer.String())
+}
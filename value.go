@@ -0,0 +1,49 @@
+package flags
+
+import "flag"
+
+type value[T any] struct {
+	output *T
+	parse  func(string) (T, error)
+	format func(T) string
+}
+
+func newValue[T any](val T, p *T, parse func(string) (T, error), format func(T) string) *value[T] {
+	*p = val
+
+	return &value[T]{
+		output: p,
+		parse:  parse,
+		format: format,
+	}
+}
+
+func (v *value[T]) String() string {
+	if v == nil || v.output == nil {
+		return ""
+	}
+
+	return v.format(*v.output)
+}
+
+func (v *value[T]) Get() any {
+	return *v.output
+}
+
+func (v *value[T]) Set(input string) error {
+	parsed, err := v.parse(input)
+	if err != nil {
+		return err
+	}
+
+	*v.output = parsed
+
+	return nil
+}
+
+func valueVar[T any](b Builder, fs *flag.FlagSet, output *T, value T, overrides []Override, parse func(string) (T, error), format func(T) string) {
+	flagName, envName, usage := b.computeDescription(fs)
+
+	fs.Var(newValue(defaultStaticValue(b.name, value, overrides), output, parse, format), flagName, usage)
+	bind(b, fs, flagName, envName, usage, output, overrides, parse)
+}
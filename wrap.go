@@ -0,0 +1,61 @@
+package flags
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultTerminalWidth = 80
+	minDescriptionWidth  = 20
+)
+
+func terminalWidth(output io.Writer) int {
+	file, ok := output.(*os.File)
+	if !ok {
+		return 0
+	}
+
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return 0
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	return defaultTerminalWidth
+}
+
+func wrap(text string, indent, width int) string {
+	available := width - indent
+	if width <= 0 || available < minDescriptionWidth || len(text) <= available {
+		return text
+	}
+
+	var (
+		builder strings.Builder
+		lineLen int
+	)
+
+	for _, word := range strings.Fields(text) {
+		if lineLen > 0 && lineLen+1+len(word) > available {
+			builder.WriteString("\n")
+			builder.WriteString(strings.Repeat(" ", indent))
+			lineLen = 0
+		}
+
+		if lineLen > 0 {
+			builder.WriteString(" ")
+			lineLen++
+		}
+
+		builder.WriteString(word)
+		lineLen += len(word)
+	}
+
+	return builder.String()
+}
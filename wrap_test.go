@@ -0,0 +1,51 @@
+package flags
+
+import "testing"
+
+func TestWrap(t *testing.T) {
+	cases := map[string]struct {
+		input  string
+		indent int
+		width  int
+		want   string
+	}{
+		"should not wrap without width": {
+			"Listen address of the server",
+			4,
+			0,
+			"Listen address of the server",
+		},
+		"should not wrap short text": {
+			"Listen address",
+			4,
+			80,
+			"Listen address",
+		},
+		"should not wrap below minimum width": {
+			"Listen address of the server",
+			70,
+			80,
+			"Listen address of the server",
+		},
+		"should wrap with hanging indent": {
+			"Listen address of the server, with its port",
+			4,
+			34,
+			"Listen address of the server,\n    with its port",
+		},
+		"should keep long word on its own line": {
+			"Url ${A_VERY_LONG_ENVIRONMENT_VARIABLE_NAME}",
+			2,
+			24,
+			"Url\n  ${A_VERY_LONG_ENVIRONMENT_VARIABLE_NAME}",
+		},
+	}
+
+	for intention, tc := range cases {
+		t.Run(intention, func(t *testing.T) {
+			if result := wrap(tc.input, tc.indent, tc.width); result != tc.want {
+				t.Errorf("wrap() = `%s`, want `%s`", result, tc.want)
+			}
+		})
+	}
+}